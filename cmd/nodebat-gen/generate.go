@@ -0,0 +1,620 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mfaizudd/nodebat-go/validation"
+)
+
+// kind is how the generator handles the value of a field
+type kind int
+
+const (
+	// kindOther values are validated through the builder, like the runtime path
+	kindOther kind = iota
+	kindString
+	kindNumber
+	kindSlice
+)
+
+// field is a struct field with its rules
+type field struct {
+	goName string
+	name   string
+	rules  []validation.Rule
+	tag    string
+	kind   kind
+	// nested is the struct type reached through the field, if it is declared in the package
+	nested string
+	// container is how the nested struct is reached: "", "ptr", "slice" or "map"
+	container string
+	// elemPtr is set if the elements of the container are pointers
+	elemPtr bool
+}
+
+type generator struct {
+	pkg     string
+	dir     string
+	structs map[string]*ast.StructType
+	// files are the files declaring the structs, to resolve the packages of external types
+	files map[string]*ast.File
+	// importer loads the packages of external types from source, on first use
+	importer types.ImporterFrom
+	fields   map[string][]field
+	buf      bytes.Buffer
+	imports  map[string]bool
+}
+
+// generate parses the package in dir and returns the source of the generated file
+func generate(dir string, typeNames []string, output string) ([]byte, error) {
+	fset := token.NewFileSet()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	g := &generator{
+		dir:     dir,
+		structs: map[string]*ast.StructType{},
+		files:   map[string]*ast.File{},
+		fields:  map[string][]field{},
+		imports: map[string]bool{},
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") || name == output {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		if g.pkg == "" {
+			g.pkg = file.Name.Name
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			spec, ok := n.(*ast.TypeSpec)
+			if !ok {
+				return true
+			}
+			if st, ok := spec.Type.(*ast.StructType); ok && spec.TypeParams == nil {
+				g.structs[spec.Name.Name] = st
+				g.files[spec.Name.Name] = file
+			}
+			return false
+		})
+	}
+	if g.pkg == "" {
+		return nil, fmt.Errorf("no Go files in %s", dir)
+	}
+
+	names := make([]string, 0, len(g.structs))
+	for name := range g.structs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fields, err := g.parseFields(name)
+		if err != nil {
+			return nil, err
+		}
+		g.fields[name] = fields
+	}
+
+	if len(typeNames) == 0 {
+		for _, name := range names {
+			if g.hasRules(name, map[string]bool{}) {
+				typeNames = append(typeNames, name)
+			}
+		}
+	}
+	for _, name := range typeNames {
+		if _, ok := g.structs[name]; !ok {
+			return nil, fmt.Errorf("struct type %s not found", name)
+		}
+	}
+	if len(typeNames) == 0 {
+		return nil, fmt.Errorf("no struct with validate tags in %s", dir)
+	}
+
+	// every struct reachable from the requested types needs a nodebatValidate method
+	reachable := map[string]bool{}
+	var visit func(name string)
+	visit = func(name string) {
+		if reachable[name] {
+			return
+		}
+		reachable[name] = true
+		for _, f := range g.fields[name] {
+			if f.nested != "" && g.hasRules(f.nested, map[string]bool{}) {
+				visit(f.nested)
+			}
+		}
+	}
+	for _, name := range typeNames {
+		visit(name)
+	}
+
+	sort.Strings(typeNames)
+	for _, name := range typeNames {
+		g.printf("// Validate validates %s using the rules of its validate tags\n", name)
+		g.printf("func (s *%s) Validate() error {\n", name)
+		g.printf("v := validation.New()\n")
+		g.printf("s.nodebatValidate(v, \"\")\n")
+		g.printf("return v.Error()\n")
+		g.printf("}\n\n")
+	}
+	for _, name := range names {
+		if reachable[name] {
+			g.generateType(name)
+		}
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by nodebat-gen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&out, "package %s\n\n", g.pkg)
+	fmt.Fprintf(&out, "import (\n")
+	imports := make([]string, 0, len(g.imports))
+	for path := range g.imports {
+		imports = append(imports, path)
+	}
+	sort.Strings(imports)
+	for _, path := range imports {
+		fmt.Fprintf(&out, "%q\n", path)
+	}
+	if len(imports) > 0 {
+		fmt.Fprintf(&out, "\n")
+	}
+	fmt.Fprintf(&out, "%q\n", "github.com/mfaizudd/nodebat-go/validation")
+	fmt.Fprintf(&out, ")\n\n")
+	out.Write(g.buf.Bytes())
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w\n%s", err, out.Bytes())
+	}
+	return src, nil
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+// hasRules reports whether the struct or one of its nested structs has rules
+func (g *generator) hasRules(name string, visiting map[string]bool) bool {
+	if visiting[name] {
+		return false
+	}
+	visiting[name] = true
+	for _, f := range g.fields[name] {
+		if len(f.rules) > 0 {
+			return true
+		}
+		if f.nested != "" && g.hasRules(f.nested, visiting) {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *generator) parseFields(name string) ([]field, error) {
+	fields := make([]field, 0)
+	for _, astField := range g.structs[name].Fields.List {
+		var tag reflect.StructTag
+		if astField.Tag != nil {
+			unquoted, err := strconv.Unquote(astField.Tag.Value)
+			if err != nil {
+				return nil, err
+			}
+			tag = reflect.StructTag(unquoted)
+		}
+		goNames := make([]string, 0, len(astField.Names))
+		for _, ident := range astField.Names {
+			goNames = append(goNames, ident.Name)
+		}
+		if len(goNames) == 0 {
+			// embedded fields are named after their type, like encoding/json does without flattening
+			typeName := astField.Type
+			if star, ok := typeName.(*ast.StarExpr); ok {
+				typeName = star.X
+			}
+			switch typeName := typeName.(type) {
+			case *ast.Ident:
+				goNames = append(goNames, typeName.Name)
+			case *ast.SelectorExpr:
+				goNames = append(goNames, typeName.Sel.Name)
+			default:
+				return nil, fmt.Errorf("%s: embedded field of type %s is not supported", name, types.ExprString(astField.Type))
+			}
+		}
+		for _, goName := range goNames {
+			structField := reflect.StructField{Name: goName, Tag: tag}
+			if !ast.IsExported(goName) {
+				structField.PkgPath = g.pkg
+			}
+			fieldName, ok := validation.FieldName(structField)
+			if !ok {
+				continue
+			}
+			f := field{goName: goName, name: fieldName}
+			if rules := tag.Get(validation.StructTag); rules != "" && rules != "-" {
+				parsed, err := validation.ParseRules(rules)
+				if err != nil {
+					return nil, fmt.Errorf("%s.%s: %w", name, goName, err)
+				}
+				if _, err := validation.CompileRuleSet(rules); err != nil {
+					return nil, fmt.Errorf("%s.%s: %w", name, goName, err)
+				}
+				f.rules = parsed
+				f.tag = rules
+			}
+			if err := g.classify(name, &f, astField.Type); err != nil {
+				return nil, err
+			}
+			fields = append(fields, f)
+		}
+	}
+	return fields, nil
+}
+
+func (g *generator) structName(expr ast.Expr) (string, bool) {
+	ident, ok := expr.(*ast.Ident)
+	if !ok {
+		return "", false
+	}
+	_, ok = g.structs[ident.Name]
+	return ident.Name, ok
+}
+
+func (g *generator) classify(structName string, f *field, expr ast.Expr) error {
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		switch expr.Name {
+		case "string":
+			f.kind = kindString
+		case "int", "int8", "int16", "int32", "int64",
+			"uint", "uint8", "uint16", "uint32", "uint64",
+			"float32", "float64":
+			f.kind = kindNumber
+		default:
+			if name, ok := g.structName(expr); ok {
+				f.nested = name
+			}
+		}
+	case *ast.StarExpr:
+		if name, ok := g.structName(expr.X); ok {
+			f.nested = name
+			f.container = "ptr"
+			return nil
+		}
+		return g.checkExternal(structName, f, expr.X)
+	case *ast.ArrayType:
+		if expr.Len == nil {
+			f.kind = kindSlice
+		}
+		return f.setElem(g, structName, expr.Elt, "slice")
+	case *ast.MapType:
+		return f.setElem(g, structName, expr.Value, "map")
+	case *ast.SelectorExpr:
+		return g.checkExternal(structName, f, expr)
+	}
+	return nil
+}
+
+func (f *field) setElem(g *generator, structName string, elem ast.Expr, container string) error {
+	if star, ok := elem.(*ast.StarExpr); ok {
+		elem = star.X
+		f.elemPtr = true
+	}
+	if name, ok := g.structName(elem); ok {
+		f.nested = name
+		f.container = container
+		return nil
+	}
+	return g.checkExternal(structName, f, elem)
+}
+
+// checkExternal fails if expr is a type of another package with validate tags.
+// Methods can't be generated for the types of other packages, and skipping them would
+// silently drop rules that validation.ValidateStruct applies
+func (g *generator) checkExternal(structName string, f *field, expr ast.Expr) error {
+	selector, ok := expr.(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+	pkgName, ok := selector.X.(*ast.Ident)
+	if !ok {
+		return nil
+	}
+	typeName := pkgName.Name + "." + selector.Sel.Name
+	pkg, err := g.importPackage(g.files[structName], pkgName.Name)
+	if err != nil {
+		return fmt.Errorf("%s.%s: loading the package of %s: %w", structName, f.goName, typeName, err)
+	}
+	obj, ok := pkg.Scope().Lookup(selector.Sel.Name).(*types.TypeName)
+	if !ok {
+		return fmt.Errorf("%s.%s: type %s not found", structName, f.goName, typeName)
+	}
+	if hasTags(obj.Type(), map[types.Type]bool{}) {
+		return fmt.Errorf("%s.%s: %s has validate tags but is declared in package %s, "+
+			"declare it in package %s or validate it with validation.ValidateStruct",
+			structName, f.goName, typeName, pkg.Path(), g.pkg)
+	}
+	return nil
+}
+
+// importPackage loads the package imported by file under the name pkgName
+func (g *generator) importPackage(file *ast.File, pkgName string) (*types.Package, error) {
+	if g.importer == nil {
+		g.importer = importer.ForCompiler(token.NewFileSet(), "source", nil).(types.ImporterFrom)
+	}
+	for _, spec := range file.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			return nil, err
+		}
+		if spec.Name != nil && spec.Name.Name != pkgName {
+			continue
+		}
+		// without an explicit name, the package name is only known once it is loaded
+		pkg, err := g.importer.ImportFrom(path, g.dir, 0)
+		if err != nil {
+			if spec.Name != nil {
+				return nil, err
+			}
+			continue
+		}
+		if spec.Name != nil || pkg.Name() == pkgName {
+			return pkg, nil
+		}
+	}
+	return nil, fmt.Errorf("no import named %s", pkgName)
+}
+
+// hasTags reports whether t reaches a struct with validate tags, like
+// validation.StructRules walks nested structs
+func hasTags(t types.Type, visiting map[types.Type]bool) bool {
+	if visiting[t] {
+		return false
+	}
+	visiting[t] = true
+	switch u := t.Underlying().(type) {
+	case *types.Pointer:
+		return hasTags(u.Elem(), visiting)
+	case *types.Slice:
+		return hasTags(u.Elem(), visiting)
+	case *types.Array:
+		return hasTags(u.Elem(), visiting)
+	case *types.Map:
+		return hasTags(u.Elem(), visiting)
+	case *types.Struct:
+		for i := 0; i < u.NumFields(); i++ {
+			field := u.Field(i)
+			tag := reflect.StructTag(u.Tag(i))
+			structField := reflect.StructField{Name: field.Name(), Tag: tag}
+			if !field.Exported() {
+				structField.PkgPath = field.Pkg().Path()
+			}
+			if _, ok := validation.FieldName(structField); !ok {
+				continue
+			}
+			if rules := tag.Get(validation.StructTag); rules != "" && rules != "-" {
+				return true
+			}
+			if hasTags(field.Type(), visiting) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func rulesVar(typeName string, f field) string {
+	return "nodebat" + typeName + f.goName + "Rules"
+}
+
+func (g *generator) generateType(name string) {
+	fields := g.fields[name]
+	for _, f := range fields {
+		if len(f.rules) > 0 {
+			g.printf("var %s = validation.MustCompileRuleSet(%q)\n", rulesVar(name, f), f.tag)
+		}
+	}
+	g.printf("\n")
+	g.printf("func (s *%s) nodebatValidate(v *validation.Validation, prefix string) {\n", name)
+
+	// a missing struct reports its fields as nil values, like the runtime path
+	g.printf("if s == nil {\n")
+	for _, f := range fields {
+		if len(f.rules) > 0 {
+			g.printf("%s.Apply(v.Builder(prefix+%q, (*string)(nil)))\n", rulesVar(name, f), f.name)
+		}
+		if f.nested != "" && (f.container == "" || f.container == "ptr") && g.hasRules(f.nested, map[string]bool{}) {
+			g.printf("(*%s)(nil).nodebatValidate(v, prefix+%q)\n", f.nested, f.name+".")
+		}
+	}
+	g.printf("return\n")
+	g.printf("}\n")
+
+	for _, f := range fields {
+		if len(f.rules) > 0 {
+			g.generateRules(name, f)
+		}
+		if f.nested != "" && g.hasRules(f.nested, map[string]bool{}) {
+			g.generateNested(f)
+		}
+	}
+	g.printf("}\n\n")
+}
+
+func (g *generator) generateRules(typeName string, f field) {
+	value := "s." + f.goName
+	validators := make([]string, 0, len(f.rules))
+	for _, rule := range f.rules {
+		code, ok := directRule(f.kind, value, rule)
+		if !ok {
+			// rules that can't be called directly go through the same builder as the runtime path
+			g.printf("%s.Apply(v.Builder(prefix+%q, %s))\n", rulesVar(typeName, f), f.name, value)
+			return
+		}
+		if code != "" {
+			validators = append(validators, code)
+		}
+	}
+	if len(validators) > 0 {
+		g.printf("v.Add(prefix+%q, %s)\n", f.name, strings.Join(validators, ", "))
+	}
+}
+
+func (g *generator) generateNested(f field) {
+	value := "s." + f.goName
+	prefix := strconv.Quote(f.name + ".")
+	switch f.container {
+	case "", "ptr":
+		g.printf("%s.nodebatValidate(v, prefix+%s)\n", value, prefix)
+	case "slice":
+		g.imports["strconv"] = true
+		g.printf("for i := range %s {\n", value)
+		g.printf("%s[i].nodebatValidate(v, prefix+%s+strconv.Itoa(i)+\".\")\n", value, prefix)
+		g.printf("}\n")
+	case "map":
+		g.imports["fmt"] = true
+		g.printf("for key, elem := range %s {\n", value)
+		if !f.elemPtr {
+			g.printf("elem := elem\n")
+		}
+		g.printf("elem.nodebatValidate(v, prefix+%s+fmt.Sprint(key)+\".\")\n", prefix)
+		g.printf("}\n")
+	}
+}
+
+// stringValidators are the validators without arguments that take a string, by rule name
+var stringValidators = map[string]string{
+	"is_email":        "IsEmail",
+	"is_alphanumeric": "IsAlphanumeric",
+	"is_iso8601":      "IsISO8601",
+	"is_iso8601_date": "IsISO8601Date",
+	"is_phone":        "IsPhone",
+	"is_uuid":         "IsUUID",
+	"is_only_digits":  "IsOnlyDigits",
+	"is_ip":           "IsIP",
+	"is_ipv4":         "IsIPv4",
+	"is_ipv6":         "IsIPv6",
+	"is_cidr":         "IsCIDR",
+	"is_hostname":     "IsHostname",
+	"is_fqdn":         "IsFQDN",
+	"is_mac":          "IsMAC",
+	"is_nik":          "IsNIK",
+	"is_no_kk":        "IsNoKK",
+	"is_npwp":         "IsNPWP",
+	"is_kode_pos":     "IsKodePos",
+	"is_credit_card":  "IsCreditCard",
+	"is_iban":         "IsIBAN",
+	"is_bic":          "IsBIC",
+	"is_isbn":         "IsISBN",
+	"is_gtin":         "IsGTIN",
+	"is_issn":         "IsISSN",
+	"is_time_of_day":  "IsTimeOfDay",
+}
+
+// directRule returns the code calling the typed validator of rule on value,
+// or false if the rule has to go through the builder.
+// An empty code means the rule always passes for the kind of value
+func directRule(k kind, value string, rule validation.Rule) (string, bool) {
+	switch k {
+	case kindString:
+		switch rule.Name {
+		case "required":
+			return fmt.Sprintf("validation.Required(%s)", value), true
+		case "min_length", "max_length", "length":
+			n := 1
+			if rule.Name == "length" {
+				n = 2
+			}
+			if len(rule.Args) > n {
+				// the optional last argument is the length mode
+				mode, err := validation.ParseLengthMode(rule.Args[n])
+				if err != nil {
+					return "", false
+				}
+				args := append([]string{value}, rule.Args[:n]...)
+				args = append(args, "validation."+camel(mode.String()))
+				return fmt.Sprintf("validation.%sIn(%s)", camel(rule.Name), strings.Join(args, ", ")), true
+			}
+			return fmt.Sprintf("validation.%s(%s, %s)", camel(rule.Name), value, strings.Join(rule.Args, ", ")), true
+		case "one_of":
+			args := []string{value}
+			for _, arg := range rule.Args {
+				args = append(args, strconv.Quote(arg))
+			}
+			return fmt.Sprintf("validation.OneOf(%s)", strings.Join(args, ", ")), true
+		case "is_url":
+			args := []string{value}
+			var schemes []string
+			for _, arg := range rule.Args {
+				if arg == "host" {
+					args = append(args, "validation.URLRequireHost()")
+				} else {
+					schemes = append(schemes, strconv.Quote(arg))
+				}
+			}
+			if len(schemes) > 0 {
+				args = append(args, fmt.Sprintf("validation.URLSchemes(%s)", strings.Join(schemes, ", ")))
+			}
+			return fmt.Sprintf("validation.IsURL(%s)", strings.Join(args, ", ")), true
+		default:
+			if validator, ok := stringValidators[rule.Name]; ok && len(rule.Args) == 0 {
+				return fmt.Sprintf("validation.%s(%s)", validator, value), true
+			}
+		}
+	case kindNumber:
+		switch rule.Name {
+		case "required", "numeric":
+			return "", true
+		case "min", "max", "range", "multiple_of":
+			args := []string{"float64(" + value + ")"}
+			for _, arg := range rule.Args {
+				number, err := strconv.ParseFloat(arg, 64)
+				if err != nil {
+					return "", false
+				}
+				args = append(args, strconv.FormatFloat(number, 'g', -1, 64))
+			}
+			return fmt.Sprintf("validation.%s(%s)", camel(rule.Name), strings.Join(args, ", ")), true
+		case "positive", "negative", "non_negative", "non_zero", "finite":
+			return fmt.Sprintf("validation.%s(%s)", camel(rule.Name), value), true
+		}
+	case kindSlice:
+		switch rule.Name {
+		case "required":
+			return "", true
+		case "min_count":
+			return fmt.Sprintf("validation.MinCountOf(%s, %s)", value, rule.Args[0]), true
+		case "max_count":
+			return fmt.Sprintf("validation.MaxCountOf(%s, %s)", value, rule.Args[0]), true
+		}
+	}
+	return "", false
+}
+
+// camel converts a rule name into the name of its validator, e.g. "min_length" into "MinLength"
+func camel(name string) string {
+	parts := strings.Split(name, "_")
+	for i, part := range parts {
+		if part != "" {
+			parts[i] = strings.ToUpper(part[:1]) + part[1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerateIsUpToDate(t *testing.T) {
+	dir := filepath.Join("internal", "example")
+	expected, err := os.ReadFile(filepath.Join(dir, "nodebat_validate.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual, err := generate(dir, nil, "nodebat_validate.go")
+	if err != nil {
+		t.Fatal("Expected error to be nil, got: ", err)
+	}
+	if string(actual) != string(expected) {
+		t.Fatalf("generated code is out of date, run go generate ./...\n%s", actual)
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	testCases := []struct {
+		src   string
+		types []string
+	}{
+		{"package a\n\ntype A struct {\n\tName string `validate:\"unknown\"`\n}\n", nil},
+		{"package a\n\ntype A struct {\n\tName string `validate:\"min_length:a\"`\n}\n", nil},
+		{"package a\n\ntype A struct {\n\tName string\n}\n", nil},
+		{"package a\n\ntype A struct {\n\tName string `validate:\"required\"`\n}\n", []string{"B"}},
+		{"package a\n\ntype A struct {", nil},
+		{"package a\n\ntype Box[T any] struct {\n\tV T\n}\n\ntype A struct {\n\tBox[int]\n\tName string `validate:\"required\"`\n}\n", nil},
+	}
+	for _, testCase := range testCases {
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, "a.go"), []byte(testCase.src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := generate(dir, testCase.types, "nodebat_validate.go"); err == nil {
+			t.Fatalf("expected an error for\n%s", testCase.src)
+		}
+	}
+}
+
+func TestGenerateExternalTypes(t *testing.T) {
+	// types of other packages without validate tags, like time.Time, have nothing to validate
+	src := "package a\n\nimport \"time\"\n\ntype A struct {\n\ttime.Time\n\tAt *time.Time\n\tName string `validate:\"required\"`\n}\n"
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "a.go"), []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := generate(dir, nil, "nodebat_validate.go"); err != nil {
+		t.Fatal("Expected error to be nil, got: ", err)
+	}
+
+	// their rules can't be generated, so they must not be skipped silently
+	for _, testCase := range []struct {
+		dir      string
+		contains string
+	}{
+		{"external", "Customer.Billing: example.Address has validate tags"},
+		{"embedded", "Event.Address: ex.Address has validate tags"},
+	} {
+		_, err := generate(filepath.Join("testdata", testCase.dir), nil, "nodebat_validate.go")
+		if err == nil || !strings.Contains(err.Error(), testCase.contains) {
+			t.Fatalf("expected an error containing %q, got %v", testCase.contains, err)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+// Package example is used to test the code generated by nodebat-gen
+// against validation.ValidateStruct
+package example
+
+import "time"
+
+//go:generate go run github.com/mfaizudd/nodebat-go/cmd/nodebat-gen
+
+type Address struct {
+	City   string `json:"city" validate:"required"`
+	Postal string `json:"postal_code" validate:"is_only_digits|length:5,5"`
+}
+
+type Item struct {
+	SKU      string  `json:"sku" validate:"required|is_alphanumeric"`
+	Price    float64 `json:"price" validate:"min:0"`
+	Quantity uint    `json:"quantity" validate:"range:1,100"`
+}
+
+type Order struct {
+	ID        string            `json:"id" validate:"required|is_uuid"`
+	Name      string            `json:"name" validate:"trim|required|min_length:3"`
+	Nickname  string            `json:"nickname" validate:"max_length:5,runes"`
+	Email     *string           `json:"email,omitempty" validate:"is_email"`
+	Status    string            `json:"status" validate:"one_of:open,closed"`
+	Callback  string            `json:"callback_url" validate:"is_url:https,host"`
+	Origin    *string           `json:"origin" validate:"ip_in_range:10.0.0.0/8"`
+	Billing   Address           `json:"billing"`
+	Shipping  *Address          `json:"shipping"`
+	Items     []Item            `json:"items" validate:"required|min_count:1|max_count:10"`
+	Extras    []*Item           `json:"extras"`
+	Labels    map[string]Item   `json:"labels"`
+	Total     int               `json:"total" validate:"required|numeric|min:0"`
+	Discount  string            `json:"discount" validate:"min:0"`
+	CreatedAt time.Time         `json:"created_at" validate:"min_date:2020-01-01"`
+	Notes     map[string]string `json:"notes"`
+	internal  string
+}
@@ -0,0 +1,95 @@
+package example
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mfaizudd/nodebat-go/validation"
+)
+
+func ptr[T any](v T) *T { return &v }
+
+// errorsOf returns the errors of err keyed by field
+func errorsOf(err error) map[string]*validation.FieldError {
+	if err == nil {
+		return nil
+	}
+	return err.(validation.Error).Errors()
+}
+
+func TestGeneratedMatchesRuntime(t *testing.T) {
+	testCases := []*Order{
+		nil,
+		{},
+		{
+			ID:        "6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+			Name:      " order ",
+			Nickname:  "Zoëyy",
+			Status:    "open",
+			Callback:  "https://example.com/hook",
+			Origin:    ptr("10.1.2.3"),
+			Billing:   Address{City: "Bandung", Postal: "40111"},
+			Items:     []Item{{SKU: "a1", Price: 10, Quantity: 1}},
+			Total:     10,
+			Discount:  "0",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			ID:       "not a uuid",
+			Name:     " o ",
+			Nickname: "Zoëzoë",
+			Email:    ptr("not an email"),
+			Status:   "pending",
+			Callback: "http://example.com/hook",
+			Origin:   ptr("192.168.1.1"),
+			Billing:  Address{Postal: "401"},
+			Shipping: &Address{City: "Jakarta", Postal: "abcde"},
+			Items:    []Item{{SKU: "a-1", Price: -1, Quantity: 0}, {}},
+			Extras:   []*Item{nil, {SKU: "b1", Quantity: 101}},
+			Labels:   map[string]Item{"gift": {Price: -2}},
+			Total:    -1,
+			Discount: "free",
+		},
+		{
+			Items: make([]Item, 11),
+		},
+	}
+	for i, testCase := range testCases {
+		generated := errorsOf(testCase.Validate())
+		runtime := errorsOf(validation.ValidateStruct(testCase))
+		if len(generated) != len(runtime) {
+			t.Fatalf("case %d: expected %d errors, got %d\nruntime: %v\ngenerated: %v", i, len(runtime), len(generated), runtime, generated)
+		}
+		for field, expected := range runtime {
+			actual := generated[field]
+			if actual == nil {
+				t.Fatalf("case %d: expected an error for %s", i, field)
+			}
+			if actual.Tag() != expected.Tag() || actual.Message() != expected.Message() {
+				t.Fatalf("case %d: expected %s (%s), got %s (%s)", i, expected, expected.Tag(), actual, actual.Tag())
+			}
+		}
+	}
+}
+
+func BenchmarkGenerated(b *testing.B) {
+	order := &Order{
+		ID:        "6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		Name:      "order",
+		Status:    "open",
+		Callback:  "https://example.com/hook",
+		Billing:   Address{City: "Bandung", Postal: "40111"},
+		Items:     []Item{{SKU: "a1", Price: 10, Quantity: 1}},
+		CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	b.Run("generated", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = order.Validate()
+		}
+	})
+	b.Run("runtime", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = validation.ValidateStruct(order)
+		}
+	})
+}
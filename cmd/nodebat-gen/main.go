@@ -0,0 +1,47 @@
+// Command nodebat-gen generates reflection-free Validate methods from the
+// validate struct tags of a package
+//
+// Usage:
+//
+//	//go:generate go run github.com/mfaizudd/nodebat-go/cmd/nodebat-gen -type Order,Item
+//
+// The generated methods call the typed validators directly where the rules allow it,
+// and report the same field names and tags as validation.ValidateStruct
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	typeNames := flag.String("type", "", "comma separated list of type names, defaults to every struct with validate tags")
+	output := flag.String("output", "nodebat_validate.go", "output file name, relative to the package directory")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: nodebat-gen [flags] [directory]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	dir := "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+	var types []string
+	if *typeNames != "" {
+		types = strings.Split(*typeNames, ",")
+	}
+	outputPath := filepath.Join(dir, *output)
+	src, err := generate(dir, types, filepath.Base(outputPath))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "nodebat-gen: %v\n", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(outputPath, src, 0o644); err != nil {
+		fmt.Fprintf(os.Stderr, "nodebat-gen: %v\n", err)
+		os.Exit(1)
+	}
+}
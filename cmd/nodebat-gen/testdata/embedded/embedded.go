@@ -0,0 +1,13 @@
+package embedded
+
+import (
+	"time"
+
+	ex "github.com/mfaizudd/nodebat-go/cmd/nodebat-gen/internal/example"
+)
+
+type Event struct {
+	time.Time
+	*ex.Address
+	Name string `validate:"required"`
+}
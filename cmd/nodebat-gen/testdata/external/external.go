@@ -0,0 +1,8 @@
+package external
+
+import "github.com/mfaizudd/nodebat-go/cmd/nodebat-gen/internal/example"
+
+type Customer struct {
+	Name    string            `validate:"required"`
+	Billing []example.Address `json:"billing"`
+}
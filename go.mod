@@ -2,4 +2,10 @@ module github.com/mfaizudd/nodebat-go
 
 go 1.19
 
-require github.com/google/uuid v1.3.0
+require (
+	github.com/google/uuid v1.3.0
+	github.com/rivo/uniseg v0.4.7
+	golang.org/x/net v0.28.0
+)
+
+require golang.org/x/text v0.17.0 // indirect
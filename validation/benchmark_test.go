@@ -0,0 +1,132 @@
+package validation
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+type benchStudent struct {
+	name  string
+	email string
+	phone string
+	age   int
+	born  time.Time
+}
+
+var benchValid = benchStudent{
+	name:  "student123",
+	email: "student@email.com",
+	phone: "081234123412",
+	age:   20,
+	born:  time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+}
+
+var (
+	benchMinDate = time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC)
+	benchMaxDate = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func validateWithAdd(v *Validation, s *benchStudent) {
+	v.Add("name", Required(s.name), MinLength(s.name, 3), MaxLength(s.name, 20), IsAlphanumeric(s.name))
+	v.Add("phone", Required(s.phone), IsPhone(s.phone), IsOnlyDigits(s.phone[1:]))
+	v.Add("age", Range(s.age, 1, 100))
+	v.Add("born", BetweenDate(s.born, benchMinDate, benchMaxDate))
+}
+
+// validateWithBuilder passes pointers to the builder, so the values don't need to be boxed
+func validateWithBuilder(v *Validation, s *benchStudent) {
+	v.Builder("name", &s.name).Required().MinLength(3).MaxLength(20).IsAlphanumeric()
+	v.Builder("phone", &s.phone).Required().IsPhone()
+	v.Builder("age", &s.age).RangeInt(1, 100)
+	v.Builder("born", &s.born).BetweenDate(benchMinDate, benchMaxDate)
+}
+
+func TestSuccessPathDoesNotAllocate(t *testing.T) {
+	if raceEnabled {
+		t.Skip("the race detector allocates")
+	}
+	v := New()
+	allocs := testing.AllocsPerRun(100, func() {
+		validateWithAdd(v, &benchValid)
+		validateWithBuilder(v, &benchValid)
+		if v.Error() != nil {
+			t.Fatal("Expected error to be nil, got: ", v.Error())
+		}
+		v.Reset()
+	})
+	if allocs != 0 {
+		t.Fatalf("expected no allocations on the success path, got %v", allocs)
+	}
+}
+
+func TestReset(t *testing.T) {
+	v := New()
+	v.Add("name", Required(""))
+	if v.Error() == nil {
+		t.Fatal("Expected error to be not nil")
+	}
+	v.Reset()
+	if v.Error() != nil {
+		t.Fatal("Expected error to be nil after reset, got: ", v.Error())
+	}
+	v.Add("name", Required("name"))
+	if v.Error() != nil {
+		t.Fatal("Expected error to be nil, got: ", v.Error())
+	}
+}
+
+func TestFieldErrorLazyParams(t *testing.T) {
+	err := NewFieldError("test", "test is invalid", "test", nil)
+	if err.HasParams() || err.Params() != nil || err.Param("min") != nil {
+		t.Fatal("expected no params")
+	}
+	err.SetParam("min", 1)
+	if !err.HasParams() || err.Param("min") != 1 {
+		t.Fatal("expected the min param to be set")
+	}
+}
+
+func BenchmarkAdd(b *testing.B) {
+	v := New()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		validateWithAdd(v, &benchValid)
+		_ = v.Error()
+		v.Reset()
+	}
+}
+
+func BenchmarkBuilder(b *testing.B) {
+	v := New()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		validateWithBuilder(v, &benchValid)
+		_ = v.Error()
+		v.Reset()
+	}
+}
+
+func BenchmarkPooled(b *testing.B) {
+	pool := sync.Pool{New: func() interface{} { return New() }}
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			v := pool.Get().(*Validation)
+			validateWithBuilder(v, &benchValid)
+			_ = v.Error()
+			v.Reset()
+			pool.Put(v)
+		}
+	})
+}
+
+func BenchmarkFailure(b *testing.B) {
+	v := New()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		v.Builder("name", "a b").Required().MinLength(3).IsAlphanumeric()
+		_ = v.Error()
+		v.Reset()
+	}
+}
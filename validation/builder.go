@@ -1,20 +1,25 @@
 package validation
 
-import "time"
+import (
+	"math/big"
+	"time"
+)
 
 type Builder struct {
 	validation *Validation
 	field      string
 	value      interface{}
+	lengthMode LengthMode
+	dateLayout string
 }
 
 // NewBuilder creates a new Builder
 func NewBuilder(v *Validation, field string, value interface{}) *Builder {
-	return &Builder{v, field, value}
+	return &Builder{validation: v, field: field, value: value}
 }
 
 // add adds an error to the validation object
-// Required checks if the data is nil or empty string
+// Required checks if the data is nil, a nil pointer, an empty string or a pointer to one
 func (v *Builder) Required() *Builder {
 	if v.hasError() {
 		return v
@@ -23,24 +28,36 @@ func (v *Builder) Required() *Builder {
 	return v
 }
 
-// Min checks if the data is at least min
+// Min checks if the data is at least min. Exact numbers and decimal strings are compared
+// exactly, like the min rule, see MinDecimal
 //
 // Has one parameter: min (int64)
 func (v *Builder) Min(min int64) *Builder {
+	if v.isDecimal() {
+		return v.MinDecimal(big.NewRat(min, 1))
+	}
 	return v.MinInt(min)
 }
 
-// Max checks if the data is at most max
+// Max checks if the data is at most max. Exact numbers and decimal strings are compared
+// exactly, like the max rule, see MaxDecimal
 //
 // Has one parameter: max (int64)
 func (v *Builder) Max(max int64) *Builder {
+	if v.isDecimal() {
+		return v.MaxDecimal(big.NewRat(max, 1))
+	}
 	return v.MaxInt(max)
 }
 
-// Range checks if the data is between min and max
+// Range checks if the data is between min and max. Exact numbers and decimal strings are
+// compared exactly, like the range rule, see RangeDecimal
 //
 // Has two parameters: min and max (int64)
 func (v *Builder) Range(min, max int64) *Builder {
+	if v.isDecimal() {
+		return v.RangeDecimal(big.NewRat(min, 1), big.NewRat(max, 1))
+	}
 	return v.RangeInt(min, max)
 }
 
@@ -170,44 +187,54 @@ func (v *Builder) RangeFloat(min, max float64) *Builder {
 	return v
 }
 
-// MinLength checks if the data is at least min characters long
+// LengthMode sets how the following length validators count the length of the data,
+// overriding the mode of the validation
+func (v *Builder) LengthMode(mode LengthMode) *Builder {
+	v.lengthMode = mode
+	return v
+}
+
+// MinLength checks if the data is at least min characters long, counted using the length mode,
+// see LengthMode
 //
-// Has one parameter: min (int)
+// The error has two parameters: min (int), mode (string)
 func (v *Builder) MinLength(min int) *Builder {
 	if v.hasError() {
 		return v
 	}
 	value, ok := v.getString()
 	if ok {
-		v.validation.Add(v.field, MinLength(value, min))
+		v.validation.Add(v.field, MinLengthIn(value, min, v.getLengthMode()))
 	}
 	return v
 }
 
-// MaxLength checks if the data is at most max characters long
+// MaxLength checks if the data is at most max characters long, counted using the length mode,
+// see LengthMode
 //
-// Has one parameter: max (int)
+// The error has two parameters: max (int), mode (string)
 func (v *Builder) MaxLength(max int) *Builder {
 	if v.hasError() {
 		return v
 	}
 	value, ok := v.getString()
 	if ok {
-		v.validation.Add(v.field, MaxLength(value, max))
+		v.validation.Add(v.field, MaxLengthIn(value, max, v.getLengthMode()))
 	}
 	return v
 }
 
-// Length checks if the data is between min and max characters long
+// Length checks if the data is between min and max characters long, counted using the length
+// mode, see LengthMode
 //
-// Has one parameter: length (int)
+// The error has three parameters: min (int), max (int), mode (string)
 func (v *Builder) Length(min, max int) *Builder {
 	if v.hasError() {
 		return v
 	}
 	value, ok := v.getString()
 	if ok {
-		v.validation.Add(v.field, Length(value, min, max))
+		v.validation.Add(v.field, LengthIn(value, min, max, v.getLengthMode()))
 	}
 	return v
 }
@@ -365,7 +392,7 @@ func (v *Builder) Custom(validator Validator) *Builder {
 //
 // Has one parameter: min (int)
 func (v *Builder) MinCount(min int) *Builder {
-	if v.hasError() {
+	if v.hasError() || !v.isSet() {
 		return v
 	}
 	v.validation.Add(v.field, MinCount(v.value, min))
@@ -376,7 +403,7 @@ func (v *Builder) MinCount(min int) *Builder {
 //
 // Has one parameter: max (int)
 func (v *Builder) MaxCount(max int) *Builder {
-	if v.hasError() {
+	if v.hasError() || !v.isSet() {
 		return v
 	}
 	v.validation.Add(v.field, MaxCount(v.value, max))
@@ -385,7 +412,7 @@ func (v *Builder) MaxCount(max int) *Builder {
 
 // Numeric checks if the value is a number
 func (v *Builder) Numeric() *Builder {
-	if v.hasError() {
+	if v.hasError() || !v.isSet() {
 		return v
 	}
 	v.validation.Add(v.field, Numeric(v.value))
@@ -0,0 +1,417 @@
+package validation
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Calendar tells business days apart from weekends and holidays, e.g. for IsBusinessDay
+type Calendar interface {
+	// IsWeekend reports whether the day of date is a weekend day
+	IsWeekend(date time.Time) bool
+	// Holiday returns the name of the holiday on the day of date, if it is a holiday
+	Holiday(date time.Time) (string, bool)
+}
+
+// civilDate is a day of the calendar, without a time of day or a timezone
+type civilDate struct {
+	year  int
+	month time.Month
+	day   int
+}
+
+func civilDateOf(t time.Time) civilDate {
+	year, month, day := t.Date()
+	return civilDate{year, month, day}
+}
+
+func (d civilDate) before(other civilDate) bool {
+	if d.year != other.year {
+		return d.year < other.year
+	}
+	if d.month != other.month {
+		return d.month < other.month
+	}
+	return d.day < other.day
+}
+
+// HolidayCalendar is a Calendar with a set of weekend days and a list of holidays
+type HolidayCalendar struct {
+	weekend  map[time.Weekday]bool
+	holidays map[civilDate]string
+}
+
+// NewCalendar returns a calendar with the given weekend days and no holidays,
+// e.g. NewCalendar(time.Saturday, time.Sunday)
+func NewCalendar(weekend ...time.Weekday) *HolidayCalendar {
+	c := &HolidayCalendar{weekend: map[time.Weekday]bool{}, holidays: map[civilDate]string{}}
+	for _, day := range weekend {
+		c.weekend[day] = true
+	}
+	return c
+}
+
+// AddHoliday adds the day of date as a holiday named name
+func (c *HolidayCalendar) AddHoliday(date time.Time, name string) {
+	c.holidays[civilDateOf(date)] = name
+}
+
+// IsWeekend reports whether the day of date is one of the weekend days of the calendar
+func (c *HolidayCalendar) IsWeekend(date time.Time) bool {
+	return c.weekend[date.Weekday()]
+}
+
+// Holiday returns the name of the holiday on the day of date, if it is a holiday
+func (c *HolidayCalendar) Holiday(date time.Time) (string, bool) {
+	name, ok := c.holidays[civilDateOf(date)]
+	return name, ok
+}
+
+// defaultCalendar is used when no calendar is set, with Saturday and Sunday as the weekend
+var defaultCalendar = NewCalendar(time.Saturday, time.Sunday)
+
+func calendarOrDefault(calendar Calendar) Calendar {
+	if calendar == nil {
+		return defaultCalendar
+	}
+	return calendar
+}
+
+func isBusinessDay(calendar Calendar, date time.Time) bool {
+	if calendar.IsWeekend(date) {
+		return false
+	}
+	_, holiday := calendar.Holiday(date)
+	return !holiday
+}
+
+// calendarJSON is the format read by LoadCalendarJSON
+type calendarJSON struct {
+	Weekend  []string `json:"weekend"`
+	Holidays []struct {
+		Date string `json:"date"`
+		End  string `json:"end"`
+		Name string `json:"name"`
+	} `json:"holidays"`
+}
+
+// LoadCalendarJSON reads a calendar from JSON, with the weekend days and the holidays.
+// Holidays lasting several days have an end date, included in the holiday:
+//
+//	{
+//	  "weekend": ["saturday", "sunday"],
+//	  "holidays": [
+//	    {"date": "2026-01-01", "name": "New Year's Day"},
+//	    {"date": "2026-03-20", "end": "2026-03-21", "name": "Eid al-Fitr"}
+//	  ]
+//	}
+func LoadCalendarJSON(r io.Reader) (*HolidayCalendar, error) {
+	var file calendarJSON
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return nil, fmt.Errorf("validation: invalid calendar: %w", err)
+	}
+	calendar := NewCalendar()
+	for _, name := range file.Weekend {
+		day, ok := parseWeekday(name)
+		if !ok {
+			return nil, fmt.Errorf("validation: invalid weekend day %q", name)
+		}
+		calendar.weekend[day] = true
+	}
+	for _, holiday := range file.Holidays {
+		start, err := time.Parse("2006-01-02", holiday.Date)
+		if err != nil {
+			return nil, fmt.Errorf("validation: invalid holiday date %q", holiday.Date)
+		}
+		end := start
+		if holiday.End != "" {
+			if end, err = time.Parse("2006-01-02", holiday.End); err != nil || end.Before(start) {
+				return nil, fmt.Errorf("validation: invalid holiday end date %q", holiday.End)
+			}
+		}
+		for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+			calendar.AddHoliday(day, holiday.Name)
+		}
+	}
+	return calendar, nil
+}
+
+func parseWeekday(name string) (time.Weekday, bool) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.EqualFold(day.String(), name) {
+			return day, true
+		}
+	}
+	return 0, false
+}
+
+// LoadCalendarICal reads the holidays of a calendar from an iCalendar file, each event being
+// a holiday named after its summary. The weekend days are not part of iCalendar files, so they
+// are given by weekend
+//
+// Events end the day before their DTEND when it is a date or a date-time at midnight,
+// as in the iCalendar format. Date-times are taken on the day they fall in the timezone of
+// the calendar, given by X-WR-TIMEZONE, or else in their own timezone.
+// Recurring events are not supported and return an error
+func LoadCalendarICal(r io.Reader, weekend ...time.Weekday) (*HolidayCalendar, error) {
+	lines, err := unfoldICalLines(r)
+	if err != nil {
+		return nil, err
+	}
+	type icalEvent struct {
+		start, end time.Time
+		allDay     bool
+		summary    string
+	}
+	var events []icalEvent
+	var event icalEvent
+	var calendarLoc *time.Location
+	inEvent := false
+	for _, line := range lines {
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		name, params, _ := strings.Cut(name, ";")
+		switch strings.ToUpper(name) {
+		case "BEGIN":
+			if strings.EqualFold(value, "VEVENT") {
+				inEvent, event = true, icalEvent{}
+			}
+		case "X-WR-TIMEZONE":
+			if calendarLoc, err = time.LoadLocation(value); err != nil {
+				return nil, fmt.Errorf("validation: invalid iCalendar timezone %q", value)
+			}
+		case "DTSTART", "DTEND":
+			if !inEvent {
+				continue
+			}
+			date, allDay, err := parseICalDate(value, params)
+			if err != nil {
+				return nil, err
+			}
+			if strings.EqualFold(name, "DTSTART") {
+				event.start, event.allDay = date, allDay
+			} else {
+				event.end = date
+			}
+		case "SUMMARY":
+			if inEvent {
+				event.summary = unescapeICalText(value)
+			}
+		case "RRULE", "RDATE":
+			if inEvent {
+				return nil, errors.New("validation: recurring iCalendar events are not supported")
+			}
+		case "END":
+			if !strings.EqualFold(value, "VEVENT") || !inEvent {
+				continue
+			}
+			inEvent = false
+			if event.start.IsZero() {
+				return nil, errors.New("validation: iCalendar event without DTSTART")
+			}
+			events = append(events, event)
+		}
+	}
+	calendar := NewCalendar(weekend...)
+	for _, event := range events {
+		start, end := event.start, event.end
+		if !event.allDay && calendarLoc != nil {
+			start, end = start.In(calendarLoc), end.In(calendarLoc)
+		}
+		// DTEND is exclusive, so an event ending at midnight ends the day before
+		last := start
+		if end.After(start) {
+			last = end.Add(-time.Nanosecond)
+		}
+		year, month, firstDay := start.Date()
+		lastDay := civilDateOf(last)
+		for day := time.Date(year, month, firstDay, 0, 0, 0, 0, time.UTC); !lastDay.before(civilDateOf(day)); day = day.AddDate(0, 0, 1) {
+			calendar.AddHoliday(day, event.summary)
+		}
+	}
+	return calendar, nil
+}
+
+// unfoldICalLines reads the lines of an iCalendar file, joining the lines that continue
+// on the next one, which starts with a space or a tab
+func unfoldICalLines(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines, scanner.Err()
+}
+
+// parseICalDate parses an iCalendar date, e.g. "20260101", or date-time, e.g. "20260101T000000Z",
+// in the timezone given by the TZID param of params. It reports whether value is a date
+func parseICalDate(value string, params string) (time.Time, bool, error) {
+	if len(value) == 8 {
+		if date, err := time.Parse("20060102", value); err == nil {
+			return date, true, nil
+		}
+		return time.Time{}, false, fmt.Errorf("validation: invalid iCalendar date %q", value)
+	}
+	loc := time.UTC
+	for _, param := range strings.Split(params, ";") {
+		key, tzid, _ := strings.Cut(param, "=")
+		if !strings.EqualFold(key, "TZID") {
+			continue
+		}
+		var err error
+		if loc, err = time.LoadLocation(strings.Trim(tzid, `"`)); err != nil {
+			return time.Time{}, false, fmt.Errorf("validation: invalid iCalendar timezone %q", tzid)
+		}
+	}
+	layout := "20060102T150405"
+	if strings.HasSuffix(value, "Z") {
+		layout, loc = "20060102T150405Z", time.UTC
+	}
+	date, err := time.ParseInLocation(layout, value, loc)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("validation: invalid iCalendar date %q", value)
+	}
+	return date, false, nil
+}
+
+func unescapeICalText(value string) string {
+	return strings.NewReplacer(`\,`, ",", `\;`, ";", `\n`, " ", `\N`, " ", `\\`, `\`).Replace(value)
+}
+
+// ErrNoBusinessDay is returned by AddBusinessDays when the calendar has no business day
+// in a whole year
+var ErrNoBusinessDay = errors.New("validation: no business day in the calendar")
+
+// AddBusinessDays returns the day n business days after the day of date, at the same time of day.
+// If n is 0, it returns date
+func AddBusinessDays(calendar Calendar, date time.Time, n int) (time.Time, error) {
+	calendar = calendarOrDefault(calendar)
+	for skipped := 0; n > 0; {
+		date = date.AddDate(0, 0, 1)
+		if isBusinessDay(calendar, date) {
+			n--
+			skipped = 0
+		} else if skipped++; skipped > 366 {
+			return time.Time{}, ErrNoBusinessDay
+		}
+	}
+	return date, nil
+}
+
+// IsBusinessDay checks if the date is neither a weekend day nor a holiday of calendar,
+// a nil calendar has Saturday and Sunday as the weekend and no holidays
+//
+// Has one parameter: reason (string), "weekend" or "holiday", and for holidays,
+// another parameter: holiday (string), the name of the holiday
+func IsBusinessDay(date time.Time, calendar Calendar) Validator {
+	return func(field string) *FieldError {
+		calendar := calendarOrDefault(calendar)
+		reason := ""
+		holiday, isHoliday := calendar.Holiday(date)
+		switch {
+		case calendar.IsWeekend(date):
+			reason = "weekend"
+		case isHoliday:
+			reason = "holiday"
+		}
+		if reason != "" {
+			msg := fmt.Sprintf("%s must be a business day", field)
+			err := NewFieldError(field, msg, "is_business_day", date)
+			err.SetParam("reason", reason)
+			if isHoliday {
+				err.SetParam("holiday", holiday)
+			}
+			return err
+		}
+		return nil
+	}
+}
+
+// NotHoliday checks if the date is not a holiday of calendar, weekends are allowed
+//
+// Has one parameter: holiday (string), the name of the holiday
+func NotHoliday(date time.Time, calendar Calendar) Validator {
+	return func(field string) *FieldError {
+		if holiday, ok := calendarOrDefault(calendar).Holiday(date); ok {
+			msg := fmt.Sprintf("%s must not be a holiday", field)
+			err := NewFieldError(field, msg, "not_holiday", date)
+			err.SetParam("holiday", holiday)
+			return err
+		}
+		return nil
+	}
+}
+
+// AtLeastBusinessDaysAfter checks if the day of the date is at least n business days after the
+// day of ref, e.g. a delivery date 2 business days after the order
+//
+// Has two parameters: ref (time.Time), business_days (int), and when it can be computed,
+// another parameter: earliest (time.Time), the first allowed day
+func AtLeastBusinessDaysAfter(date time.Time, ref time.Time, n int, calendar Calendar) Validator {
+	return func(field string) *FieldError {
+		earliest, calendarErr := AddBusinessDays(calendar, ref, n)
+		if calendarErr == nil && !civilDateOf(date.In(ref.Location())).before(civilDateOf(earliest)) {
+			return nil
+		}
+		msg := fmt.Sprintf("%s must be at least %d business days after %s", field, n, ref.Format("2006-01-02"))
+		err := NewFieldError(field, msg, "min_business_days", date)
+		err.SetParam("ref", ref)
+		err.SetParam("business_days", n)
+		if calendarErr == nil {
+			err.SetParam("earliest", earliest)
+		}
+		return err
+	}
+}
+
+// IsBusinessDay checks if the date is a business day of the calendar of the validation,
+// see Validation.SetCalendar
+func (v *Builder) IsBusinessDay() *Builder {
+	if v.hasError() {
+		return v
+	}
+	value, ok := v.getTime()
+	if ok {
+		v.validation.Add(v.field, IsBusinessDay(value, v.validation.calendar))
+	}
+	return v
+}
+
+// NotHoliday checks if the date is not a holiday of the calendar of the validation
+func (v *Builder) NotHoliday() *Builder {
+	if v.hasError() {
+		return v
+	}
+	value, ok := v.getTime()
+	if ok {
+		v.validation.Add(v.field, NotHoliday(value, v.validation.calendar))
+	}
+	return v
+}
+
+// AtLeastBusinessDaysAfter checks if the date is at least n business days after ref,
+// using the calendar of the validation
+//
+// Has two parameters: ref (time.Time), n (int)
+func (v *Builder) AtLeastBusinessDaysAfter(ref time.Time, n int) *Builder {
+	if v.hasError() {
+		return v
+	}
+	value, ok := v.getTimeIn(ref.Location())
+	if ok {
+		v.validation.Add(v.field, AtLeastBusinessDaysAfter(value, ref, n, v.validation.calendar))
+	}
+	return v
+}
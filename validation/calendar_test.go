@@ -0,0 +1,203 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+const testCalendarJSON = `{
+  "weekend": ["Saturday", "sunday"],
+  "holidays": [
+    {"date": "2026-01-01", "name": "Tahun Baru"},
+    {"date": "2026-03-20", "end": "2026-03-21", "name": "Idul Fitri"},
+    {"date": "2026-08-17", "name": "Hari Kemerdekaan"}
+  ]
+}`
+
+const testCalendarICal = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART;VALUE=DATE:20260101\r\n" +
+	"DTEND;VALUE=DATE:20260102\r\n" +
+	"SUMMARY:Tahun Baru\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART;VALUE=DATE:20260320\r\n" +
+	"DTEND;VALUE=DATE:20260322\r\n" +
+	"SUMMARY:Idul Fitri\\, Hari\r\n" +
+	"  Pertama dan Kedua\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART:20260817T000000Z\r\n" +
+	"SUMMARY:Hari Kemerdekaan\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func calendarDay(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestLoadCalendar(t *testing.T) {
+	fromJSON, err := LoadCalendarJSON(strings.NewReader(testCalendarJSON))
+	if err != nil {
+		t.Fatal("Expected error to be nil, got: ", err)
+	}
+	fromICal, err := LoadCalendarICal(strings.NewReader(testCalendarICal), time.Saturday, time.Sunday)
+	if err != nil {
+		t.Fatal("Expected error to be nil, got: ", err)
+	}
+	for _, calendar := range []*HolidayCalendar{fromJSON, fromICal} {
+		for _, holiday := range []time.Time{calendarDay(2026, 1, 1), calendarDay(2026, 3, 20), calendarDay(2026, 3, 21), calendarDay(2026, 8, 17)} {
+			if _, ok := calendar.Holiday(holiday); !ok {
+				t.Fatalf(`date %v should be a holiday`, holiday)
+			}
+		}
+		for _, day := range []time.Time{calendarDay(2026, 1, 2), calendarDay(2026, 3, 22), calendarDay(2026, 8, 18)} {
+			if name, ok := calendar.Holiday(day); ok {
+				t.Fatalf(`date %v should not be a holiday, got %s`, day, name)
+			}
+		}
+		if !calendar.IsWeekend(calendarDay(2026, 10, 17)) || calendar.IsWeekend(calendarDay(2026, 10, 19)) {
+			t.Fatal("expected Saturday and Sunday to be the weekend")
+		}
+	}
+	if name, _ := fromICal.Holiday(calendarDay(2026, 3, 20)); name != "Idul Fitri, Hari Pertama dan Kedua" {
+		t.Fatalf("expected the unfolded and unescaped summary, got %q", name)
+	}
+
+	invalidJSON := []string{
+		`{"weekend": ["caturday"]}`,
+		`{"holidays": [{"date": "2026-13-01"}]}`,
+		`{"holidays": [{"date": "2026-03-21", "end": "2026-03-20"}]}`,
+		`[`,
+	}
+	for _, file := range invalidJSON {
+		if _, err := LoadCalendarJSON(strings.NewReader(file)); err == nil {
+			t.Fatalf(`calendar %s is valid, it should be invalid`, file)
+		}
+	}
+	timed := "BEGIN:VCALENDAR\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"DTSTART:20260101T000000\r\n" +
+		"DTEND:20260102T000000\r\n" +
+		"SUMMARY:Tahun Baru\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"DTSTART;TZID=Asia/Jakarta:20260817T080000\r\n" +
+		"DTEND;TZID=Asia/Jakarta:20260817T120000\r\n" +
+		"SUMMARY:Upacara\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+	fromTimed, err := LoadCalendarICal(strings.NewReader(timed))
+	if err != nil {
+		t.Fatal("Expected error to be nil, got: ", err)
+	}
+	if _, ok := fromTimed.Holiday(calendarDay(2026, 1, 1)); !ok {
+		t.Fatal("2026-01-01 should be a holiday")
+	}
+	if _, ok := fromTimed.Holiday(calendarDay(2026, 8, 17)); !ok {
+		t.Fatal("2026-08-17 should be a holiday")
+	}
+	if err := NotHoliday(calendarDay(2026, 1, 2), fromTimed)("date"); err != nil {
+		t.Fatal("an event ending at midnight should not include the next day, got: ", err)
+	}
+
+	// 17:00 UTC on December 31 is midnight on January 1 in Jakarta
+	zoned := "BEGIN:VCALENDAR\r\n" +
+		"X-WR-TIMEZONE:Asia/Jakarta\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"DTSTART:20251231T170000Z\r\n" +
+		"DTEND:20260101T170000Z\r\n" +
+		"SUMMARY:Tahun Baru\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+	fromZoned, err := LoadCalendarICal(strings.NewReader(zoned))
+	if err != nil {
+		t.Fatal("Expected error to be nil, got: ", err)
+	}
+	if _, ok := fromZoned.Holiday(calendarDay(2026, 1, 1)); !ok {
+		t.Fatal("2026-01-01 should be a holiday")
+	}
+	for _, day := range []time.Time{calendarDay(2025, 12, 31), calendarDay(2026, 1, 2)} {
+		if name, ok := fromZoned.Holiday(day); ok {
+			t.Fatalf(`date %v should not be a holiday, got %s`, day, name)
+		}
+	}
+
+	invalidICal := []string{
+		"BEGIN:VEVENT\nDTSTART;VALUE=DATE:20260101\nRRULE:FREQ=YEARLY\nEND:VEVENT\n",
+		"BEGIN:VEVENT\nDTSTART:2026\nEND:VEVENT\n",
+		"BEGIN:VEVENT\nDTSTART;TZID=Nowhere/City:20260101T000000\nEND:VEVENT\n",
+		"BEGIN:VEVENT\nSUMMARY:No date\nEND:VEVENT\n",
+	}
+	for _, file := range invalidICal {
+		if _, err := LoadCalendarICal(strings.NewReader(file)); err == nil {
+			t.Fatalf(`calendar %q is valid, it should be invalid`, file)
+		}
+	}
+}
+
+func TestBusinessDays(t *testing.T) {
+	calendar, _ := LoadCalendarJSON(strings.NewReader(testCalendarJSON))
+	testCases := []struct {
+		validator Validator
+		tag       string
+	}{
+		{IsBusinessDay(calendarDay(2026, 10, 19), calendar), ""},
+		{IsBusinessDay(calendarDay(2026, 10, 18), calendar), "is_business_day"},
+		{IsBusinessDay(calendarDay(2026, 8, 17), calendar), "is_business_day"},
+		{IsBusinessDay(calendarDay(2026, 8, 17), nil), ""},
+		{IsBusinessDay(calendarDay(2026, 8, 16), nil), "is_business_day"},
+		{NotHoliday(calendarDay(2026, 10, 18), calendar), ""},
+		{NotHoliday(calendarDay(2026, 3, 21), calendar), "not_holiday"},
+		// Thursday August 13 + 2 business days is Tuesday August 18, after the weekend and the holiday
+		{AtLeastBusinessDaysAfter(calendarDay(2026, 8, 18), calendarDay(2026, 8, 13), 2, calendar), ""},
+		{AtLeastBusinessDaysAfter(calendarDay(2026, 8, 20), calendarDay(2026, 8, 13), 2, calendar), ""},
+		{AtLeastBusinessDaysAfter(calendarDay(2026, 8, 17), calendarDay(2026, 8, 13), 2, calendar), "min_business_days"},
+		{AtLeastBusinessDaysAfter(calendarDay(2026, 8, 17), calendarDay(2026, 8, 13), 2, nil), ""},
+		{AtLeastBusinessDaysAfter(calendarDay(2026, 8, 13), calendarDay(2026, 8, 13), 0, calendar), ""},
+		{AtLeastBusinessDaysAfter(calendarDay(2026, 8, 13), calendarDay(2026, 8, 13), 1, NewCalendar(time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday)), "min_business_days"},
+	}
+	for i, testCase := range testCases {
+		err := testCase.validator("delivery")
+		if testCase.tag == "" && err != nil {
+			t.Fatalf(`case %d is invalid, it should be valid: %v`, i, err)
+		}
+		if testCase.tag != "" && (err == nil || err.Tag() != testCase.tag) {
+			t.Fatalf(`case %d should be invalid with %s, got %v`, i, testCase.tag, err)
+		}
+	}
+
+	err := IsBusinessDay(calendarDay(2026, 8, 17), calendar)("delivery")
+	if err.Param("reason") != "holiday" || err.Param("holiday") != "Hari Kemerdekaan" {
+		t.Fatalf("expected the holiday reason and name, got %v", err.Params())
+	}
+	err = AtLeastBusinessDaysAfter(calendarDay(2026, 8, 17), calendarDay(2026, 8, 13), 2, calendar)("delivery")
+	if err.Param("earliest") != calendarDay(2026, 8, 18) || err.Param("business_days") != 2 {
+		t.Fatalf("expected the earliest day and the number of business days, got %v", err.Params())
+	}
+}
+
+func TestBusinessDaysBuilder(t *testing.T) {
+	calendar, _ := LoadCalendarJSON(strings.NewReader(testCalendarJSON))
+	v := New()
+	v.SetCalendar(calendar)
+	v.SetClock(FixedClock(time.Date(2026, 8, 13, 15, 0, 0, 0, time.UTC)))
+	v.Builder("delivery", "2026-08-18").IsBusinessDay().NotHoliday().AtLeastBusinessDaysAfter(calendarDay(2026, 8, 13), 2)
+	v.Builder("rules", "2026-08-18").Rules("is_business_day|not_holiday|min_business_days:2")
+	if err := v.Error(); err != nil {
+		t.Fatal("Expected error to be nil, got: ", err)
+	}
+
+	v.Builder("holiday", "2026-08-17").Rules("not_holiday")
+	v.Builder("too_soon", "2026-08-14").Rules("min_business_days:2")
+	errs := v.Error().(Error).Errors()
+	if errs["holiday"].Tag() != "not_holiday" || errs["too_soon"].Tag() != "min_business_days" {
+		t.Fatalf("expected not_holiday and min_business_days, got %v", errs)
+	}
+
+	if _, err := CompileRuleSet("min_business_days:x"); err == nil {
+		t.Fatal("Expected error to be not nil")
+	}
+}
@@ -0,0 +1,408 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// CheckDigitAlgorithm checks the check digits of a value, e.g. Luhn
+type CheckDigitAlgorithm interface {
+	// Name is the name used in rule strings and in the "algorithm" param, e.g. "luhn"
+	Name() string
+	// Valid reports whether the last characters of value are its valid check digits
+	Valid(value string) bool
+}
+
+type checkDigitFunc struct {
+	name  string
+	valid func(string) bool
+}
+
+func (c checkDigitFunc) Name() string {
+	return c.name
+}
+
+func (c checkDigitFunc) Valid(value string) bool {
+	return c.valid(value)
+}
+
+// CheckDigitFunc returns a CheckDigitAlgorithm named name that uses valid to check values
+func CheckDigitFunc(name string, valid func(value string) bool) CheckDigitAlgorithm {
+	return checkDigitFunc{name, valid}
+}
+
+var (
+	// Luhn is the mod 10 algorithm used by card numbers and IMEI numbers
+	Luhn = CheckDigitFunc("luhn", luhnValid)
+	// Verhoeff is the dihedral group algorithm, which detects all single digit errors
+	// and all transpositions of adjacent digits
+	Verhoeff = CheckDigitFunc("verhoeff", verhoeffValid)
+	// Damm is the quasigroup algorithm, which detects all single digit errors
+	// and all transpositions of adjacent digits
+	Damm = CheckDigitFunc("damm", dammValid)
+	// Mod97_10 is ISO 7064 MOD 97-10, with two check digits, used by IBAN and LEI.
+	// Uppercase letters count as two digits, A as 10 to Z as 35
+	Mod97_10 = CheckDigitFunc("mod97_10", mod97_10Valid)
+	// Mod11_2 is ISO 7064 MOD 11-2, with a check digit that is a digit or X, used by ORCID and ISNI
+	Mod11_2 = CheckDigitFunc("mod11_2", mod11_2Valid)
+	// GTIN is the mod 10 algorithm with weights 3 and 1 used by EAN, UPC, GTIN and ISBN-13
+	GTIN = CheckDigitFunc("gtin", gtinValid)
+	// ISBN10 is the mod 11 algorithm used by ISBN-10, with a check digit that is a digit or X
+	ISBN10 = CheckDigitFunc("isbn10", func(value string) bool { return len(value) == 10 && weightedMod11Valid(value) })
+	// ISSN is the mod 11 algorithm used by ISSN, with a check digit that is a digit or X
+	ISSN = CheckDigitFunc("issn", func(value string) bool { return len(value) == 8 && weightedMod11Valid(value) })
+)
+
+var checkDigitAlgorithms = struct {
+	sync.RWMutex
+	byName map[string]CheckDigitAlgorithm
+}{byName: map[string]CheckDigitAlgorithm{}}
+
+func init() {
+	for _, algorithm := range []CheckDigitAlgorithm{Luhn, Verhoeff, Damm, Mod97_10, Mod11_2, GTIN, ISBN10, ISSN} {
+		RegisterCheckDigit(algorithm)
+	}
+}
+
+// RegisterCheckDigit makes algorithm available to the check_digit rule under its name,
+// replacing any algorithm with the same name
+func RegisterCheckDigit(algorithm CheckDigitAlgorithm) {
+	checkDigitAlgorithms.Lock()
+	defer checkDigitAlgorithms.Unlock()
+	checkDigitAlgorithms.byName[algorithm.Name()] = algorithm
+}
+
+// LookupCheckDigit returns the algorithm registered under name
+func LookupCheckDigit(name string) (CheckDigitAlgorithm, bool) {
+	checkDigitAlgorithms.RLock()
+	defer checkDigitAlgorithms.RUnlock()
+	algorithm, ok := checkDigitAlgorithms.byName[name]
+	return algorithm, ok
+}
+
+// isDigits reports whether value has at least min characters, all of them digits
+func isDigits(value string, min int) bool {
+	if len(value) < min {
+		return false
+	}
+	for i := 0; i < len(value); i++ {
+		if value[i] < '0' || value[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func luhnValid(value string) bool {
+	if !isDigits(value, 2) {
+		return false
+	}
+	sum := 0
+	double := false
+	for i := len(value) - 1; i >= 0; i-- {
+		d := int(value[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+var verhoeffMultiplication = [10][10]byte{
+	{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+	{1, 2, 3, 4, 0, 6, 7, 8, 9, 5},
+	{2, 3, 4, 0, 1, 7, 8, 9, 5, 6},
+	{3, 4, 0, 1, 2, 8, 9, 5, 6, 7},
+	{4, 0, 1, 2, 3, 9, 5, 6, 7, 8},
+	{5, 9, 8, 7, 6, 0, 4, 3, 2, 1},
+	{6, 5, 9, 8, 7, 1, 0, 4, 3, 2},
+	{7, 6, 5, 9, 8, 2, 1, 0, 4, 3},
+	{8, 7, 6, 5, 9, 3, 2, 1, 0, 4},
+	{9, 8, 7, 6, 5, 4, 3, 2, 1, 0},
+}
+
+var verhoeffPermutation = [8][10]byte{
+	{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+	{1, 5, 7, 6, 2, 8, 3, 0, 9, 4},
+	{5, 8, 0, 3, 7, 9, 6, 1, 4, 2},
+	{8, 9, 1, 6, 0, 4, 3, 5, 2, 7},
+	{9, 4, 5, 3, 1, 2, 6, 8, 7, 0},
+	{4, 2, 8, 6, 5, 7, 3, 9, 0, 1},
+	{2, 7, 9, 3, 8, 0, 6, 4, 1, 5},
+	{7, 0, 4, 6, 9, 1, 3, 2, 5, 8},
+}
+
+func verhoeffValid(value string) bool {
+	if !isDigits(value, 2) {
+		return false
+	}
+	var c byte
+	for i := 0; i < len(value); i++ {
+		d := value[len(value)-1-i] - '0'
+		c = verhoeffMultiplication[c][verhoeffPermutation[i%8][d]]
+	}
+	return c == 0
+}
+
+var dammTable = [10][10]byte{
+	{0, 3, 1, 7, 5, 9, 8, 6, 4, 2},
+	{7, 0, 9, 2, 1, 5, 4, 8, 6, 3},
+	{4, 2, 0, 6, 8, 7, 1, 3, 5, 9},
+	{1, 7, 5, 0, 9, 8, 3, 4, 2, 6},
+	{6, 1, 2, 3, 0, 4, 5, 9, 7, 8},
+	{3, 6, 7, 4, 2, 0, 9, 5, 8, 1},
+	{5, 8, 6, 9, 7, 2, 0, 1, 3, 4},
+	{8, 9, 4, 5, 3, 6, 2, 0, 1, 7},
+	{9, 4, 3, 8, 6, 1, 7, 2, 0, 5},
+	{2, 5, 8, 1, 4, 3, 6, 7, 9, 0},
+}
+
+func dammValid(value string) bool {
+	if !isDigits(value, 2) {
+		return false
+	}
+	var interim byte
+	for i := 0; i < len(value); i++ {
+		interim = dammTable[interim][value[i]-'0']
+	}
+	return interim == 0
+}
+
+func mod97_10Valid(value string) bool {
+	if len(value) < 3 || !isDigits(value[len(value)-2:], 2) {
+		return false
+	}
+	remainder := 0
+	for i := 0; i < len(value); i++ {
+		switch c := value[i]; {
+		case c >= '0' && c <= '9':
+			remainder = (remainder*10 + int(c-'0')) % 97
+		case c >= 'A' && c <= 'Z':
+			remainder = (remainder*100 + int(c-'A'+10)) % 97
+		default:
+			return false
+		}
+	}
+	return remainder == 1
+}
+
+// checkCharValue returns the value of a check character that may be X for 10
+func checkCharValue(c byte) (int, bool) {
+	switch {
+	case c >= '0' && c <= '9':
+		return int(c - '0'), true
+	case c == 'X' || c == 'x':
+		return 10, true
+	}
+	return 0, false
+}
+
+func mod11_2Valid(value string) bool {
+	if len(value) < 2 || !isDigits(value[:len(value)-1], 1) {
+		return false
+	}
+	check, ok := checkCharValue(value[len(value)-1])
+	if !ok {
+		return false
+	}
+	p := 0
+	for i := 0; i < len(value)-1; i++ {
+		p = (p + int(value[i]-'0')) * 2 % 11
+	}
+	return (12-p)%11 == check
+}
+
+func gtinValid(value string) bool {
+	if !isDigits(value, 2) {
+		return false
+	}
+	sum := 0
+	for i := 0; i < len(value); i++ {
+		d := int(value[len(value)-1-i] - '0')
+		if i%2 == 1 {
+			d *= 3
+		}
+		sum += d
+	}
+	return sum%10 == 0
+}
+
+// weightedMod11Valid checks the mod 11 check digit of ISBN-10 and ISSN, where the digits
+// are weighted from the length of value down to 1 and the check digit may be X
+func weightedMod11Valid(value string) bool {
+	if len(value) < 2 || !isDigits(value[:len(value)-1], 1) {
+		return false
+	}
+	check, ok := checkCharValue(value[len(value)-1])
+	if !ok {
+		return false
+	}
+	sum := check
+	for i := 0; i < len(value)-1; i++ {
+		sum += int(value[i]-'0') * (len(value) - i)
+	}
+	return sum%11 == 0
+}
+
+// CheckDigit checks if the data ends with valid check digits for algorithm, e.g. Luhn.
+// The value is checked as is, formatting characters like spaces must be removed first
+//
+// Has one parameter: algorithm (string), the name of the algorithm
+func CheckDigit(algorithm CheckDigitAlgorithm, value string) Validator {
+	return func(field string) *FieldError {
+		if !algorithm.Valid(value) {
+			msg := fmt.Sprintf("%s has an invalid check digit", field)
+			err := NewFieldError(field, msg, "check_digit", value)
+			err.SetParam("algorithm", algorithm.Name())
+			return err
+		}
+		return nil
+	}
+}
+
+// removeHyphensAndSpaces removes the separators of ISBN and GTIN values, e.g. "978-0-306-40615-7"
+func removeHyphensAndSpaces(value string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, value)
+}
+
+// IsISBN checks if the data is an ISBN-10 or an ISBN-13 with a valid check digit.
+// Hyphens and spaces are ignored. If versions are given, e.g. 13, the ISBN must be one of them
+//
+// Has one parameter: versions ([]int), if given
+func IsISBN(value string, versions ...int) Validator {
+	return func(field string) *FieldError {
+		isbn := removeHyphensAndSpaces(value)
+		valid := false
+		switch len(isbn) {
+		case 10:
+			valid = allowsLength(versions, 10) && ISBN10.Valid(isbn)
+		case 13:
+			valid = allowsLength(versions, 13) && (strings.HasPrefix(isbn, "978") || strings.HasPrefix(isbn, "979")) &&
+				GTIN.Valid(isbn)
+		}
+		if !valid {
+			msg := fmt.Sprintf("%s is not a valid ISBN", field)
+			err := NewFieldError(field, msg, "is_isbn", value)
+			if len(versions) > 0 {
+				err.SetParam("versions", versions)
+			}
+			return err
+		}
+		return nil
+	}
+}
+
+// IsGTIN checks if the data is a GTIN-8, GTIN-12 (UPC-A), GTIN-13 (EAN-13) or GTIN-14 with a
+// valid check digit. If lengths are given, e.g. 8 and 13 for EAN, the GTIN must have one of them
+//
+// Has one parameter: lengths ([]int), if given
+func IsGTIN(value string, lengths ...int) Validator {
+	return func(field string) *FieldError {
+		valid := false
+		switch len(value) {
+		case 8, 12, 13, 14:
+			valid = allowsLength(lengths, len(value)) && GTIN.Valid(value)
+		}
+		if !valid {
+			msg := fmt.Sprintf("%s is not a valid GTIN", field)
+			err := NewFieldError(field, msg, "is_gtin", value)
+			if len(lengths) > 0 {
+				err.SetParam("lengths", lengths)
+			}
+			return err
+		}
+		return nil
+	}
+}
+
+// allowsLength reports whether length is one of lengths, or lengths is empty
+func allowsLength(lengths []int, length int) bool {
+	if len(lengths) == 0 {
+		return true
+	}
+	for _, allowed := range lengths {
+		if allowed == length {
+			return true
+		}
+	}
+	return false
+}
+
+// IsISSN checks if the data is an ISSN with a valid check digit, e.g. "0317-8471".
+// The hyphen is optional
+func IsISSN(value string) Validator {
+	return func(field string) *FieldError {
+		issn := value
+		if len(issn) == 9 && issn[4] == '-' {
+			issn = issn[:4] + issn[5:]
+		}
+		if !ISSN.Valid(issn) {
+			msg := fmt.Sprintf("%s is not a valid ISSN", field)
+			return NewFieldError(field, msg, "is_issn", value)
+		}
+		return nil
+	}
+}
+
+// CheckDigit checks if the data ends with valid check digits for algorithm
+func (v *Builder) CheckDigit(algorithm CheckDigitAlgorithm) *Builder {
+	if v.hasError() {
+		return v
+	}
+	value, ok := v.getString()
+	if ok {
+		v.validation.Add(v.field, CheckDigit(algorithm, value))
+	}
+	return v
+}
+
+// IsISBN checks if the data is an ISBN-10 or an ISBN-13 with a valid check digit
+//
+// Has one parameter: versions (...int), the allowed versions, 10 or 13
+func (v *Builder) IsISBN(versions ...int) *Builder {
+	if v.hasError() {
+		return v
+	}
+	value, ok := v.getString()
+	if ok {
+		v.validation.Add(v.field, IsISBN(value, versions...))
+	}
+	return v
+}
+
+// IsGTIN checks if the data is a GTIN-8, GTIN-12, GTIN-13 or GTIN-14 with a valid check digit
+//
+// Has one parameter: lengths (...int), the allowed lengths
+func (v *Builder) IsGTIN(lengths ...int) *Builder {
+	if v.hasError() {
+		return v
+	}
+	value, ok := v.getString()
+	if ok {
+		v.validation.Add(v.field, IsGTIN(value, lengths...))
+	}
+	return v
+}
+
+// IsISSN checks if the data is an ISSN with a valid check digit
+func (v *Builder) IsISSN() *Builder {
+	if v.hasError() {
+		return v
+	}
+	value, ok := v.getString()
+	if ok {
+		v.validation.Add(v.field, IsISSN(value))
+	}
+	return v
+}
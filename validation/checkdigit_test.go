@@ -0,0 +1,166 @@
+package validation
+
+import "testing"
+
+func TestCheckDigitAlgorithms(t *testing.T) {
+	testCases := []struct {
+		algorithm CheckDigitAlgorithm
+		valid     []string
+		invalid   []string
+	}{
+		{Luhn, []string{"79927398713", "4111111111111111", "18"}, []string{"79927398710", "4111111111111112", "7", "7992739871a", ""}},
+		{Verhoeff, []string{"2363", "1428570", "84736430954837284567892"}, []string{"2364", "2633", "3", "23a3"}},
+		{Damm, []string{"5724", "1124"}, []string{"5727", "7524", "0", "57 4"}},
+		{Mod97_10, []string{"12345676", "3214282912345698765432161182", "DE44500105175407324931"[4:] + "DE44"}, []string{"12345677", "12345A76", "1234567a", "01"}},
+		{Mod11_2, []string{"0000000218250097", "000000021694233X", "000000021694233x"}, []string{"0000000218250098", "0000000216942330", "X", "00000002182500Y7"}},
+		{GTIN, []string{"4006381333931", "96385074", "036000291452", "10614141000415"}, []string{"4006381333932", "9638507", "40063813339a1"}},
+		{ISBN10, []string{"0306406152", "080442957X"}, []string{"0306406153", "9780306406157", "03064061X2"}},
+		{ISSN, []string{"03178471", "2434561X"}, []string{"03178472", "031784710"}},
+	}
+	for _, testCase := range testCases {
+		for _, value := range testCase.valid {
+			if !testCase.algorithm.Valid(value) {
+				t.Fatalf(`%s: value %q is invalid, it should be valid`, testCase.algorithm.Name(), value)
+			}
+		}
+		for _, value := range testCase.invalid {
+			if testCase.algorithm.Valid(value) {
+				t.Fatalf(`%s: value %q is valid, it should be invalid`, testCase.algorithm.Name(), value)
+			}
+		}
+	}
+}
+
+func TestCheckDigit(t *testing.T) {
+	if err := CheckDigit(Verhoeff, "2363")("code"); err != nil {
+		t.Fatal("Expected error to be nil, got: ", err)
+	}
+	err := CheckDigit(Verhoeff, "2364")("code")
+	if err == nil || err.Tag() != "check_digit" || err.Param("algorithm") != "verhoeff" {
+		t.Fatalf("expected a check_digit error with the verhoeff algorithm, got %v", err)
+	}
+}
+
+func TestRegisterCheckDigit(t *testing.T) {
+	evenSum := CheckDigitFunc("test_even_sum", func(value string) bool {
+		sum := 0
+		for _, c := range value {
+			sum += int(c - '0')
+		}
+		return sum%2 == 0
+	})
+	RegisterCheckDigit(evenSum)
+	if algorithm, ok := LookupCheckDigit("test_even_sum"); !ok || algorithm.Name() != "test_even_sum" {
+		t.Fatalf("expected the registered algorithm, got %v", algorithm)
+	}
+
+	v := New()
+	v.Builder("even", "1232").Rules("check_digit:test_even_sum")
+	v.Builder("odd", "1233").Rules("check_digit:test_even_sum")
+	v.Builder("luhn", "79927398713").Rules("check_digit:luhn")
+	errs, ok := v.Error().(Error)
+	if !ok || len(errs.Errors()) != 1 || errs.Errors()["odd"] == nil {
+		t.Fatalf("expected only odd to be invalid, got %v", v.Error())
+	}
+
+	invalidRules := []string{"check_digit", "check_digit:unknown", "check_digit:luhn,damm"}
+	for _, rules := range invalidRules {
+		if _, err := CompileRuleSet(rules); err == nil {
+			t.Fatalf(`rule %q is valid, it should be invalid`, rules)
+		}
+	}
+}
+
+func TestIsISBN(t *testing.T) {
+	testCases := []struct {
+		validator Validator
+		valid     bool
+	}{
+		{IsISBN("0-306-40615-2"), true},
+		{IsISBN("0 8044 2957 X"), true},
+		{IsISBN("978-0-306-40615-7"), true},
+		{IsISBN("9790306406156"), true},
+		{IsISBN("978-0-306-40615-7", 13), true},
+		{IsISBN("0-306-40615-2", 13), false},
+		{IsISBN("978-0-306-40615-8"), false},
+		{IsISBN("4006381333931"), false},
+		{IsISBN("0-306-40615-3"), false},
+		{IsISBN("030640615"), false},
+	}
+	for i, testCase := range testCases {
+		err := testCase.validator("isbn")
+		if testCase.valid && err != nil {
+			t.Fatalf(`case %d is invalid, it should be valid: %v`, i, err)
+		}
+		if !testCase.valid && (err == nil || err.Tag() != "is_isbn") {
+			t.Fatalf(`case %d is valid, it should be invalid`, i)
+		}
+	}
+}
+
+func TestIsGTIN(t *testing.T) {
+	testCases := []struct {
+		validator Validator
+		valid     bool
+	}{
+		{IsGTIN("96385074"), true},
+		{IsGTIN("036000291452"), true},
+		{IsGTIN("4006381333931"), true},
+		{IsGTIN("10614141000415"), true},
+		{IsGTIN("4006381333931", 8, 13), true},
+		{IsGTIN("10614141000415", 8, 13), false},
+		{IsGTIN("4006381333932"), false},
+		{IsGTIN("4006-381333931"), false},
+		{IsGTIN("18"), false},
+	}
+	for i, testCase := range testCases {
+		err := testCase.validator("barcode")
+		if testCase.valid && err != nil {
+			t.Fatalf(`case %d is invalid, it should be valid: %v`, i, err)
+		}
+		if !testCase.valid && (err == nil || err.Tag() != "is_gtin") {
+			t.Fatalf(`case %d is valid, it should be invalid`, i)
+		}
+	}
+
+	err := IsGTIN("10614141000415", 8, 13)("barcode")
+	if lengths := err.Param("lengths").([]int); len(lengths) != 2 {
+		t.Fatalf("expected the lengths param, got %v", err.Params())
+	}
+}
+
+func TestIsISSN(t *testing.T) {
+	valid := []string{"0317-8471", "03178471", "2434-561X"}
+	for _, value := range valid {
+		if err := IsISSN(value)("issn"); err != nil {
+			t.Fatalf(`value %q is invalid, it should be valid`, value)
+		}
+	}
+	invalid := []string{"0317-8472", "031-78471", "0317 8471", "2434-5610", ""}
+	for _, value := range invalid {
+		if err := IsISSN(value)("issn"); err == nil || err.Tag() != "is_issn" {
+			t.Fatalf(`value %q is valid, it should be invalid`, value)
+		}
+	}
+}
+
+func TestCheckDigitBuilder(t *testing.T) {
+	v := New()
+	v.Builder("code", "5724").CheckDigit(Damm)
+	v.Builder("isbn", "978-0-306-40615-7").IsISBN(13)
+	v.Builder("ean", "4006381333931").IsGTIN(13)
+	v.Builder("issn", "0317-8471").IsISSN()
+	v.Builder("rules", "96385074").Rules("is_gtin:8,13")
+	v.Builder("rules_isbn", "0306406152").Rules("is_isbn")
+	v.Builder("rules_issn", "03178471").Rules("is_issn")
+	if err := v.Error(); err != nil {
+		t.Fatal("Expected error to be nil, got: ", err)
+	}
+
+	invalidRules := []string{"is_isbn:11", "is_gtin:9", "is_gtin:x", "is_issn:8"}
+	for _, rules := range invalidRules {
+		if _, err := CompileRuleSet(rules); err == nil {
+			t.Fatalf(`rule %q is valid, it should be invalid`, rules)
+		}
+	}
+}
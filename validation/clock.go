@@ -0,0 +1,40 @@
+package validation
+
+import "time"
+
+// Clock tells the current time to the validators that depend on it, e.g. IsCardExpiry
+//
+// The location of the returned time is the timezone used to tell calendar days apart,
+// e.g. by Today and MinAge
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc is a function that implements Clock
+type ClockFunc func() time.Time
+
+// Now returns the result of f
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+// SystemClock is the clock used when none is set, it returns time.Now
+var SystemClock Clock = ClockFunc(time.Now)
+
+// ClockIn returns a clock that returns the time of clock in loc, e.g. the timezone of the user
+func ClockIn(clock Clock, loc *time.Location) Clock {
+	return ClockFunc(func() time.Time { return now(clock).In(loc) })
+}
+
+// FixedClock returns a clock that always returns t, e.g. for tests
+func FixedClock(t time.Time) Clock {
+	return ClockFunc(func() time.Time { return t })
+}
+
+// now returns the current time of clock, or of the system clock if it is nil
+func now(clock Clock) time.Time {
+	if clock == nil {
+		return SystemClock.Now()
+	}
+	return clock.Now()
+}
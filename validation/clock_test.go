@@ -0,0 +1,22 @@
+package validation
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClock(t *testing.T) {
+	fixed := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	if now(FixedClock(fixed)) != fixed {
+		t.Fatal("expected the fixed clock to return its time")
+	}
+	before := time.Now()
+	if current := now(nil); current.Before(before) {
+		t.Fatalf("expected the system clock to return the current time, got %v", current)
+	}
+
+	jakarta := time.FixedZone("WIB", 7*60*60)
+	if current := now(ClockIn(FixedClock(fixed), jakarta)); !current.Equal(fixed) || current.Location() != jakarta {
+		t.Fatalf("expected the fixed time in WIB, got %v", current)
+	}
+}
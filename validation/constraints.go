@@ -23,3 +23,9 @@ type Integer interface {
 type Number interface {
 	Integer | Float
 }
+
+// Comparable is a number type compared with a Cmp method returning -1, 0 or +1,
+// like *big.Int, *big.Rat, *big.Float or third-party decimal types, see MinCmp
+type Comparable[T any] interface {
+	Cmp(other T) int
+}
@@ -0,0 +1,104 @@
+package validation
+
+import (
+	"fmt"
+	"time"
+)
+
+// DateOrder is how the builders read ambiguous dates like "03/04/2024"
+type DateOrder int
+
+const (
+	// MonthFirst reads "03/04/2024" as March 4, it is the default
+	MonthFirst DateOrder = iota
+	// DayFirst reads "03/04/2024" as April 3, like in Indonesia and most of Europe
+	DayFirst
+)
+
+// DefaultDateLayouts returns the layouts tried in order by the builders to parse dates
+// when no layouts are set on the validation, see Validation.SetDateLayouts
+func DefaultDateLayouts(order DateOrder) []string {
+	layouts := []string{
+		time.RFC3339,
+		"2006-01-02",
+		"2006-01-02 15:04:05",
+		"2006-01-02T15:04:05Z",
+		"2006-01-02T15:04:05",
+	}
+	if order == DayFirst {
+		return append(layouts,
+			"02/01/2006",
+			"02-01-2006",
+			"02/01/2006 15:04:05",
+			"02-01-2006 15:04:05",
+		)
+	}
+	return append(layouts,
+		"01/02/2006",
+		"01-02-2006",
+		"01/02/2006 15:04:05",
+		"01-02-2006 15:04:05",
+	)
+}
+
+// dateLayouts returns the layouts used by the builders to parse dates
+func (v *Validation) dateLayouts() []string {
+	if v.layouts != nil {
+		return v.layouts
+	}
+	return DefaultDateLayouts(v.dateOrder)
+}
+
+// parseTime parses value with the first of layouts that matches, in the location of the
+// validation for layouts without a timezone, or else in def, or else in UTC
+func (v *Validation) parseTime(value string, layouts []string, def *time.Location) (time.Time, bool) {
+	loc := v.location
+	if loc == nil {
+		loc = def
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+	for _, layout := range layouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// DateFormat checks if the data is a date written exactly in layout, e.g. "02/01/2006"
+//
+// Has one parameter: layout (string)
+func DateFormat(date string, layout string) Validator {
+	return func(field string) *FieldError {
+		if _, err := time.Parse(layout, date); err != nil {
+			msg := fmt.Sprintf("%s must be a date formatted as %s", field, layout)
+			err := NewFieldError(field, msg, "date_format", date)
+			err.SetParam("layout", layout)
+			return err
+		}
+		return nil
+	}
+}
+
+// DateFormat checks if the data is a date written exactly in layout, and makes the following
+// date validators parse the data with layout only, instead of the layouts of the validation.
+// Values that are already a time.Time are not checked
+//
+// Has one parameter: layout (string)
+func (v *Builder) DateFormat(layout string) *Builder {
+	v.dateLayout = layout
+	if v.hasError() {
+		return v
+	}
+	switch v.value.(type) {
+	case time.Time, *time.Time:
+		return v
+	}
+	value, ok := v.getString()
+	if ok {
+		v.validation.Add(v.field, DateFormat(value, layout))
+	}
+	return v
+}
@@ -0,0 +1,87 @@
+package validation
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDateOrder(t *testing.T) {
+	var parsed time.Time
+	v := New()
+	v.Builder("date", "03/04/2024").IntoTime(&parsed)
+	if parsed.Month() != time.March || parsed.Day() != 4 {
+		t.Fatalf("expected March 4 by default, got %v", parsed)
+	}
+
+	v = New()
+	v.SetDateOrder(DayFirst)
+	v.Builder("date", "03/04/2024").IntoTime(&parsed)
+	v.Builder("datetime", "03-04-2024 10:30:00").MinDate(time.Date(2024, 4, 3, 10, 0, 0, 0, time.UTC))
+	if err := v.Error(); err != nil {
+		t.Fatal("Expected error to be nil, got: ", err)
+	}
+	if parsed.Month() != time.April || parsed.Day() != 3 {
+		t.Fatalf("expected April 3 with DayFirst, got %v", parsed)
+	}
+
+	v.Builder("invalid", "13/13/2024").IntoTime(&parsed)
+	if err := v.Error(); err == nil || err.(Error).Errors()["invalid"].Tag() != "invalid_time" {
+		t.Fatalf("expected an invalid_time error, got %v", err)
+	}
+}
+
+func TestDateLayoutsAndLocation(t *testing.T) {
+	jakarta := time.FixedZone("WIB", 7*60*60)
+	var parsed, withZone time.Time
+	v := New()
+	v.SetDateLayouts("02.01.2006 15:04", time.RFC3339)
+	v.SetLocation(jakarta)
+	v.Builder("date", "03.04.2024 10:30").IntoTime(&parsed)
+	v.Builder("with_zone", "2024-04-03T10:30:00Z").IntoTime(&withZone)
+	if err := v.Error(); err != nil {
+		t.Fatal("Expected error to be nil, got: ", err)
+	}
+	if !parsed.Equal(time.Date(2024, 4, 3, 3, 30, 0, 0, time.UTC)) || parsed.Location() != jakarta {
+		t.Fatalf("expected 10:30 in WIB, got %v", parsed)
+	}
+	if !withZone.Equal(time.Date(2024, 4, 3, 10, 30, 0, 0, time.UTC)) {
+		t.Fatalf("expected the timezone of the value to be kept, got %v", withZone)
+	}
+
+	v.Builder("default_layout", "2024-04-03").IntoTime(&parsed)
+	if err := v.Error(); err == nil {
+		t.Fatal("expected the default layouts to be replaced")
+	}
+}
+
+func TestDateFormat(t *testing.T) {
+	if err := DateFormat("03/04/2024", "02/01/2006")("date"); err != nil {
+		t.Fatal("Expected error to be nil, got: ", err)
+	}
+	err := DateFormat("2024-04-03", "02/01/2006")("date")
+	if err == nil || err.Tag() != "date_format" || err.Param("layout") != "02/01/2006" {
+		t.Fatalf("expected a date_format error with the layout, got %v", err)
+	}
+
+	var parsed time.Time
+	v := New()
+	v.Builder("date", "03/04/2024").DateFormat("02/01/2006").MinDate(time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)).IntoTime(&parsed)
+	v.Builder("time", time.Now()).DateFormat("02/01/2006").InPast()
+	v.Builder("rules", "2024.04.03").Rules("date_format:2006.01.02|min_date:2024-04-01")
+	if err := v.Error(); err != nil {
+		t.Fatal("Expected error to be nil, got: ", err)
+	}
+	if parsed.Month() != time.April || parsed.Day() != 3 {
+		t.Fatalf("expected April 3, got %v", parsed)
+	}
+
+	v = New()
+	v.Builder("date", "2024-04-03").DateFormat("02/01/2006").MinDate(time.Time{})
+	if err := v.Error(); err == nil || err.(Error).Errors()["date"].Tag() != "date_format" {
+		t.Fatalf("expected a date_format error, got %v", err)
+	}
+
+	if _, err := CompileRuleSet("date_format"); err == nil {
+		t.Fatal("Expected error to be not nil")
+	}
+}
@@ -0,0 +1,336 @@
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// maxDecimalExponent limits the exponent of the decimals parsed by ParseDecimal, as
+// numbers like "1e999999999" take a lot of memory to be represented exactly
+const maxDecimalExponent = 1000
+
+// ParseDecimal parses a decimal number like "12345678901234567890.12" or "1.5e-3" exactly.
+// Fractions like "1/3", non-decimal numbers like "0x10" and exponents over 1000 are invalid
+func ParseDecimal(value string) (*big.Rat, error) {
+	digits := strings.TrimLeft(value, "+-")
+	if strings.ContainsAny(value, "/_") || len(digits) > 1 && digits[0] == '0' && strings.ContainsAny(digits[1:2], "bBoOxX") {
+		return nil, invalidDecimal(value)
+	}
+	if i := strings.IndexAny(value, "eE"); i >= 0 {
+		exponent, err := strconv.Atoi(value[i+1:])
+		if err != nil || exponent > maxDecimalExponent || exponent < -maxDecimalExponent {
+			return nil, invalidDecimal(value)
+		}
+	}
+	r, ok := new(big.Rat).SetString(value)
+	if !ok {
+		return nil, invalidDecimal(value)
+	}
+	return r, nil
+}
+
+func invalidDecimal(value string) error {
+	return fmt.Errorf("validation: invalid decimal %q", value)
+}
+
+// formatDecimal writes r as a decimal, e.g. "0.01" instead of "1/100". Numbers
+// without a finite decimal form are rounded to 10 decimal places
+func formatDecimal(r *big.Rat) string {
+	if r.IsInt() {
+		return r.Num().String()
+	}
+	// 1/(2^a*5^b) has max(a, b) decimal places
+	denom := new(big.Int).Set(r.Denom())
+	twos := int(denom.TrailingZeroBits())
+	denom.Rsh(denom, uint(twos))
+	five, rem := big.NewInt(5), new(big.Int)
+	fives := 0
+	for {
+		quo, _ := new(big.Int).QuoRem(denom, five, rem)
+		if rem.Sign() != 0 {
+			break
+		}
+		denom = quo
+		fives++
+	}
+	if denom.Cmp(big.NewInt(1)) != 0 {
+		return r.FloatString(10)
+	}
+	if fives > twos {
+		twos = fives
+	}
+	return r.FloatString(twos)
+}
+
+// formatComparable writes the value of a Comparable for error messages
+func formatComparable(value interface{}) string {
+	if r, ok := value.(*big.Rat); ok {
+		return formatDecimal(r)
+	}
+	return fmt.Sprint(value)
+}
+
+// MinCmp checks if the data is at least min, comparing with Cmp, e.g. *big.Int or *big.Rat
+// A nil data is missing and not checked, use Required for it. A nil bound panics
+//
+// Has one parameter: min (same type as data)
+func MinCmp[T Comparable[T]](data T, min T) Validator {
+	mustNotBeNil("MinCmp", "min", min)
+	return func(field string) *FieldError {
+		if isNilNumber(data) {
+			return nil
+		}
+		if data.Cmp(min) < 0 {
+			msg := fmt.Sprintf("%s must be at least %s", field, formatComparable(min))
+			err := NewFieldError(field, msg, "min", data)
+			err.SetParam("min", min)
+			return err
+		}
+		return nil
+	}
+}
+
+// MaxCmp checks if the data is at most max, comparing with Cmp
+// A nil data is missing and not checked, use Required for it. A nil bound panics
+//
+// Has one parameter: max (same type as data)
+func MaxCmp[T Comparable[T]](data T, max T) Validator {
+	mustNotBeNil("MaxCmp", "max", max)
+	return func(field string) *FieldError {
+		if isNilNumber(data) {
+			return nil
+		}
+		if data.Cmp(max) > 0 {
+			msg := fmt.Sprintf("%s must be at most %s", field, formatComparable(max))
+			err := NewFieldError(field, msg, "max", data)
+			err.SetParam("max", max)
+			return err
+		}
+		return nil
+	}
+}
+
+// RangeCmp checks if the data is between min and max, comparing with Cmp
+// A nil data is missing and not checked, use Required for it. A nil bound panics
+//
+// Has two parameters: min, max (same type as data)
+func RangeCmp[T Comparable[T]](data T, min T, max T) Validator {
+	mustNotBeNil("RangeCmp", "min", min)
+	mustNotBeNil("RangeCmp", "max", max)
+	return func(field string) *FieldError {
+		if isNilNumber(data) {
+			return nil
+		}
+		if data.Cmp(min) < 0 || data.Cmp(max) > 0 {
+			msg := fmt.Sprintf("%s must be between %s and %s", field, formatComparable(min), formatComparable(max))
+			err := NewFieldError(field, msg, "range", data)
+			err.SetParam("min", min)
+			err.SetParam("max", max)
+			return err
+		}
+		return nil
+	}
+}
+
+// isNilNumber reports whether value is a nil *big.Int, *big.Rat or *big.Float
+func isNilNumber(value interface{}) bool {
+	switch value := value.(type) {
+	case *big.Int:
+		return value == nil
+	case *big.Rat:
+		return value == nil
+	case *big.Float:
+		return value == nil
+	}
+	return false
+}
+
+// mustNotBeNil panics if the bound named param of the validator fn is a nil number,
+// which has no value to compare with
+func mustNotBeNil(fn, param string, bound interface{}) {
+	if isNilNumber(bound) {
+		panic(fmt.Sprintf("validation: %s called with a nil %s", fn, param))
+	}
+}
+
+// MultipleOfRat checks if the data is exactly a multiple of step, e.g. an amount in
+// steps of 0.01
+// A nil data is missing and not checked, a nil step panics
+//
+// Has one parameter: multiple_of (*big.Rat)
+func MultipleOfRat(data *big.Rat, step *big.Rat) Validator {
+	mustNotBeNil("MultipleOfRat", "step", step)
+	return func(field string) *FieldError {
+		if data == nil {
+			return nil
+		}
+		var multiple bool
+		if step.Sign() == 0 {
+			multiple = data.Sign() == 0
+		} else {
+			multiple = new(big.Rat).Quo(data, step).IsInt()
+		}
+		if !multiple {
+			msg := fmt.Sprintf("%s must be a multiple of %s", field, formatDecimal(step))
+			err := NewFieldError(field, msg, "multiple_of", data)
+			err.SetParam("multiple_of", step)
+			return err
+		}
+		return nil
+	}
+}
+
+// isDecimal reports whether the value is one of the types carrying exact numbers, or a
+// decimal string such as "12345678901234567890.12", which the rules compare exactly
+// instead of as float64
+func (v *Builder) isDecimal() bool {
+	switch val := v.value.(type) {
+	case *big.Int, *big.Rat, *big.Float, json.Number, *json.Number:
+		return true
+	case string:
+		return isDecimalString(val)
+	case *string:
+		return val != nil && isDecimalString(*val)
+	}
+	return false
+}
+
+func isDecimalString(value string) bool {
+	_, err := ParseDecimal(value)
+	return err == nil
+}
+
+// getRat returns the value as an exact number, from a *big.Int, *big.Rat, *big.Float,
+// json.Number, any Go number or a decimal string, see ParseDecimal
+// A nil pointer is not an error, but the bool is false
+func (v *Builder) getRat() (*big.Rat, bool) {
+	switch val := v.value.(type) {
+	case *big.Rat:
+		return val, val != nil
+	case *big.Int:
+		if val == nil {
+			return nil, false
+		}
+		return new(big.Rat).SetInt(val), true
+	case *big.Float:
+		if val == nil {
+			return nil, false
+		}
+		if val.IsInf() {
+			v.add("Invalid decimal", "invalid_decimal")
+			return nil, false
+		}
+		r, _ := val.Rat(nil)
+		return r, true
+	case json.Number:
+		return v.parseRat(string(val))
+	case *json.Number:
+		if val == nil {
+			return nil, false
+		}
+		return v.parseRat(string(*val))
+	case int, int8, int16, int32, int64, *int, *int8, *int16, *int32, *int64:
+		value, ok := v.getInt()
+		if !ok {
+			return nil, false
+		}
+		return new(big.Rat).SetInt64(value), true
+	case uint, uint8, uint16, uint32, uint64, *uint, *uint8, *uint16, *uint32, *uint64:
+		value, ok := v.getUint()
+		if !ok {
+			return nil, false
+		}
+		return new(big.Rat).SetUint64(value), true
+	case float32, float64, *float32, *float64:
+		value, ok := v.getFloat()
+		if !ok {
+			return nil, false
+		}
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			v.add("Invalid decimal", "invalid_decimal")
+			return nil, false
+		}
+		return new(big.Rat).SetFloat64(value), true
+	case *string:
+		if val == nil {
+			return nil, false
+		}
+		return v.parseRat(*val)
+	default:
+		value, ok := v.getString()
+		if !ok {
+			return nil, false
+		}
+		return v.parseRat(value)
+	}
+}
+
+func (v *Builder) parseRat(value string) (*big.Rat, bool) {
+	r, err := ParseDecimal(value)
+	if err != nil {
+		v.add("Invalid decimal", "invalid_decimal")
+		return nil, false
+	}
+	return r, true
+}
+
+// MinDecimal checks if the data is at least min, comparing exactly. The data can be
+// a *big.Int, *big.Rat, *big.Float, json.Number, any Go number or a decimal string
+//
+// Has one parameter: min (*big.Rat)
+func (v *Builder) MinDecimal(min *big.Rat) *Builder {
+	if v.hasError() {
+		return v
+	}
+	value, ok := v.getRat()
+	if ok {
+		v.validation.Add(v.field, MinCmp(value, min))
+	}
+	return v
+}
+
+// MaxDecimal checks if the data is at most max, comparing exactly, see MinDecimal
+//
+// Has one parameter: max (*big.Rat)
+func (v *Builder) MaxDecimal(max *big.Rat) *Builder {
+	if v.hasError() {
+		return v
+	}
+	value, ok := v.getRat()
+	if ok {
+		v.validation.Add(v.field, MaxCmp(value, max))
+	}
+	return v
+}
+
+// RangeDecimal checks if the data is between min and max, comparing exactly, see MinDecimal
+//
+// Has two parameters: min and max (*big.Rat)
+func (v *Builder) RangeDecimal(min, max *big.Rat) *Builder {
+	if v.hasError() {
+		return v
+	}
+	value, ok := v.getRat()
+	if ok {
+		v.validation.Add(v.field, RangeCmp(value, min, max))
+	}
+	return v
+}
+
+// MultipleOfDecimal checks if the data is exactly a multiple of step, see MinDecimal
+//
+// Has one parameter: multiple_of (*big.Rat)
+func (v *Builder) MultipleOfDecimal(step *big.Rat) *Builder {
+	if v.hasError() {
+		return v
+	}
+	value, ok := v.getRat()
+	if ok {
+		v.validation.Add(v.field, MultipleOfRat(value, step))
+	}
+	return v
+}
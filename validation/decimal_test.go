@@ -0,0 +1,237 @@
+package validation
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func rat(value string) *big.Rat {
+	r, err := ParseDecimal(value)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+func TestParseDecimal(t *testing.T) {
+	valid := map[string]string{
+		"12345678901234567890.12": "308641972530864197253/25",
+		"-0.5":                    "-1/2",
+		"+10":                     "10/1",
+		".25":                     "1/4",
+		"1.5e-3":                  "3/2000",
+		"2E2":                     "200/1",
+		"007":                     "7/1",
+	}
+	for value, expected := range valid {
+		r, err := ParseDecimal(value)
+		if err != nil {
+			t.Fatalf(`value %q is invalid, it should be valid: %v`, value, err)
+		}
+		if r.String() != expected {
+			t.Fatalf(`value %q should be %s, got %s`, value, expected, r)
+		}
+	}
+	invalid := []string{"", "abc", "1/3", "0x10", "-0b1", "1_000", "1e1001", "1e-99999999999", "NaN", "Inf", "1.2.3", " 1"}
+	for _, value := range invalid {
+		if _, err := ParseDecimal(value); err == nil {
+			t.Fatalf(`value %q is valid, it should be invalid`, value)
+		}
+	}
+}
+
+func TestCmpValidators(t *testing.T) {
+	huge, _ := new(big.Int).SetString("12345678901234567890", 10)
+	testCases := []struct {
+		validator Validator
+		tag       string
+	}{
+		{MinCmp(huge, big.NewInt(0)), ""},
+		{MinCmp(huge, new(big.Int).Add(huge, big.NewInt(1))), "min"},
+		{MaxCmp(huge, huge), ""},
+		{MaxCmp(rat("0.30000000000000000001"), rat("0.3")), "max"},
+		{RangeCmp(rat("12345678901234567890.12"), rat("0"), rat("12345678901234567890.12")), ""},
+		{RangeCmp(rat("12345678901234567890.13"), rat("0"), rat("12345678901234567890.12")), "range"},
+		{RangeCmp(big.NewFloat(1.5), big.NewFloat(1), big.NewFloat(2)), ""},
+		{MultipleOfRat(rat("12345678901234567890.12"), rat("0.01")), ""},
+		{MultipleOfRat(rat("12345678901234567890.125"), rat("0.01")), "multiple_of"},
+		{MultipleOfRat(rat("0"), rat("0")), ""},
+		{MultipleOfRat(rat("1"), rat("0")), "multiple_of"},
+		// a nil number is missing, like the nil pointers of the builders
+		{MinCmp((*big.Int)(nil), big.NewInt(0)), ""},
+		{MaxCmp((*big.Float)(nil), big.NewFloat(0)), ""},
+		{RangeCmp((*big.Rat)(nil), rat("0"), rat("1")), ""},
+		{MultipleOfRat(nil, rat("0.01")), ""},
+	}
+	for i, testCase := range testCases {
+		err := testCase.validator("amount")
+		if testCase.tag == "" && err != nil {
+			t.Fatalf(`case %d is invalid, it should be valid: %v`, i, err)
+		}
+		if testCase.tag != "" && (err == nil || err.Tag() != testCase.tag) {
+			t.Fatalf(`case %d should be invalid with %s, got %v`, i, testCase.tag, err)
+		}
+	}
+
+	err := MinCmp(rat("0.005"), rat("0.01"))("amount")
+	if err.Message() != "amount must be at least 0.01" || err.Param("min").(*big.Rat).Cmp(rat("0.01")) != 0 {
+		t.Fatalf("expected the min param and a decimal message, got %q %v", err.Message(), err.Params())
+	}
+	err = MultipleOfRat(rat("0.5"), big.NewRat(1, 3))("amount")
+	if err.Message() != "amount must be a multiple of 0.3333333333" {
+		t.Fatalf("expected a rounded message, got %q", err.Message())
+	}
+}
+
+func TestCmpValidatorsNilBound(t *testing.T) {
+	for name, build := range map[string]func(){
+		"MinCmp":        func() { MinCmp(big.NewInt(1), nil) },
+		"MaxCmp":        func() { MaxCmp(rat("1"), nil) },
+		"RangeCmp":      func() { RangeCmp(rat("1"), rat("0"), nil) },
+		"MultipleOfRat": func() { MultipleOfRat(rat("1"), nil) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("expected %s to panic with a nil bound", name)
+				}
+			}()
+			build()
+		}()
+	}
+}
+
+func TestDecimalBuilder(t *testing.T) {
+	huge, _ := new(big.Int).SetString("98765432109876543210", 10)
+	var missing *big.Rat
+	v := New()
+	v.Builder("string", "12345678901234567890.12").MinDecimal(rat("0")).MaxDecimal(rat("12345678901234567890.12")).MultipleOfDecimal(rat("0.01"))
+	v.Builder("json", json.Number("12345678901234567890.12")).Rules("numeric|min:0|max:12345678901234567890.12|multiple_of:0.01")
+	v.Builder("int", huge).Rules("numeric|range:1,98765432109876543210")
+	v.Builder("rat", big.NewRat(1, 4)).Rules("multiple_of:0.05")
+	v.Builder("float", big.NewFloat(2.5)).RangeDecimal(rat("2.5"), rat("2.5"))
+	v.Builder("plain", 42).MinDecimal(rat("41.99")).Rules("min:42")
+	v.Builder("missing", missing).MinDecimal(rat("0")).Rules("min:0")
+	if err := v.Error(); err != nil {
+		t.Fatal("Expected error to be nil, got: ", err)
+	}
+
+	v = New()
+	v.Builder("json", json.Number("12345678901234567890.13")).Rules("max:12345678901234567890.12")
+	v.Builder("cents", "10.005").MultipleOfDecimal(rat("0.01"))
+	v.Builder("fraction", "1/3").MinDecimal(rat("0"))
+	v.Builder("overflow", huge).MinInt(0)
+	errs := v.Error().(Error).Errors()
+	expected := map[string]string{"json": "max", "cents": "multiple_of", "fraction": "invalid_decimal", "overflow": "invalid_integer"}
+	for field, tag := range expected {
+		if errs[field] == nil || errs[field].Tag() != tag {
+			t.Fatalf("expected %s to be invalid with %s, got %v", field, tag, errs[field])
+		}
+	}
+	if _, ok := errs["json"].Param("max").(*big.Rat); !ok {
+		t.Fatalf("expected an exact max param, got %T", errs["json"].Param("max"))
+	}
+
+	for _, value := range []interface{}{(*big.Int)(nil), (*big.Rat)(nil), new(big.Float).SetInf(false)} {
+		if err := Numeric(value)("amount"); err == nil {
+			t.Fatalf(`value %v is numeric, it should not be`, value)
+		}
+	}
+
+	if _, err := CompileRuleSet("min:0x10"); err == nil {
+		t.Fatal("Expected error to be not nil")
+	}
+}
+
+func TestDecimalStrings(t *testing.T) {
+	v := New()
+	v.Builder("amount", "12345678901234567890.12").Rules("min:0|max:12345678901234567890.12|multiple_of:0.01")
+	v.Builder("range", ptr("5000000.01")).Rules("range:0,5000000.01")
+	v.Builder("inf", "-inf").Rules("max:0")
+	if err := v.Error(); err != nil {
+		t.Fatal("Expected error to be nil, got: ", err)
+	}
+
+	v = New()
+	v.Builder("amount", "12345678901234567890.13").Rules("max:12345678901234567890.12")
+	v.Builder("cents", "5000000.005").Rules("multiple_of:0.01")
+	v.Builder("range", "5000000.02").Rules("range:0,5000000.01")
+	expected := map[string]string{"amount": "max", "cents": "multiple_of", "range": "range"}
+	errs := v.Error().(Error).Errors()
+	for field, tag := range expected {
+		if errs[field] == nil || errs[field].Tag() != tag {
+			t.Fatalf("expected %s to be invalid with %s, got %v", field, tag, errs[field])
+		}
+	}
+
+	// the builder methods take the same exact path as the rules
+	huge, _ := new(big.Int).SetString("98765432109876543210", 10)
+	v = New()
+	v.Builder("amount", "5000000.01").Min(0).Range(0, 5000001).MultipleOf(0.01)
+	v.Builder("huge", huge).Min(0)
+	if err := v.Error(); err != nil {
+		t.Fatal("Expected error to be nil, got: ", err)
+	}
+	v = New()
+	v.Builder("max", "9223372036854775807.5").Max(math.MaxInt64)
+	v.Builder("range", "9007199254740992.5").Range(0, 9007199254740992)
+	v.Builder("cents", "5000000.005").MultipleOf(0.01)
+	expected = map[string]string{"max": "max", "range": "range", "cents": "multiple_of"}
+	errs = v.Error().(Error).Errors()
+	for field, tag := range expected {
+		if errs[field] == nil || errs[field].Tag() != tag {
+			t.Fatalf("expected %s to be invalid with %s, got %v", field, tag, errs[field])
+		}
+	}
+
+	rules := MustCompileMapRules(map[string]string{"amount": "required|max:12345678901234567890.12"})
+	err := rules.Validate(map[string]interface{}{"amount": "12345678901234567890.13"})
+	if err == nil || err.(Error).Errors()["amount"].Tag() != "max" {
+		t.Fatalf("expected a max error, got %v", err)
+	}
+}
+
+func TestDecimalFloatPrecision(t *testing.T) {
+	v := New()
+	v.Builder("int", big.NewInt(9007199254740992)).MaxFloat(9007199254740992)
+	v.Builder("rat", rat("0.1")).Positive().MaxFloat(0.1)
+	v.Builder("float", big.NewFloat(2.5)).RangeFloat(2.5, 2.5)
+	if err := v.Error(); err != nil {
+		t.Fatal("Expected error to be nil, got: ", err)
+	}
+
+	// 1 + 2^-80 needs more than the 53 bits of a float64
+	precise := new(big.Float).SetMantExp(big.NewFloat(1), -80).SetPrec(100)
+	precise.Add(precise, big.NewFloat(1))
+	v = New()
+	v.Builder("int", big.NewInt(9007199254740993)).MaxFloat(9007199254740992)
+	v.Builder("rat", big.NewRat(1, 3)).Positive()
+	v.Builder("money", rat("12345678901234567890.12")).MaxFloat(1e20)
+	v.Builder("float", precise).MinFloat(0)
+	errs := v.Error().(Error).Errors()
+	for _, field := range []string{"int", "rat", "money", "float"} {
+		if errs[field] == nil || errs[field].Tag() != "invalid_float" {
+			t.Fatalf("expected %s to be invalid with invalid_float, got %v", field, errs[field])
+		}
+	}
+}
+
+func TestDecimalStruct(t *testing.T) {
+	type entry struct {
+		Amount *big.Rat    `json:"amount" validate:"required|min:0.01|multiple_of:0.01"`
+		Total  json.Number `json:"total" validate:"max:99999999999999999999.99"`
+	}
+	v := New()
+	v.Struct(entry{Amount: rat("0.10"), Total: "99999999999999999999.99"})
+	if err := v.Error(); err != nil {
+		t.Fatal("Expected error to be nil, got: ", err)
+	}
+	v = New()
+	v.Struct(entry{Amount: rat("0.001"), Total: "100000000000000000000"})
+	errs := v.Error().(Error).Errors()
+	if errs["amount"].Tag() != "min" || errs["total"].Tag() != "max" {
+		t.Fatalf("expected min and max errors, got %v", errs)
+	}
+}
@@ -0,0 +1,302 @@
+package validation
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseDuration parses a duration in the Go syntax, e.g. "1h30m", or in the ISO 8601 syntax,
+// e.g. "PT1H30M" or "P1DT12H"
+//
+// ISO 8601 durations may have weeks, days, hours, minutes and seconds, a day being 24 hours.
+// Years and months are rejected since they don't have a fixed length
+func ParseDuration(s string) (time.Duration, error) {
+	if d, err := time.ParseDuration(s); err == nil {
+		return d, nil
+	}
+	return parseISODuration(s)
+}
+
+// isoDurationUnits are the designators of ISO 8601 durations, in the order they must appear
+var isoDurationUnits = []struct {
+	designator byte
+	inTime     bool
+	unit       time.Duration
+}{
+	{'W', false, 7 * 24 * time.Hour},
+	{'D', false, 24 * time.Hour},
+	{'H', true, time.Hour},
+	{'M', true, time.Minute},
+	{'S', true, time.Second},
+}
+
+func parseISODuration(s string) (time.Duration, error) {
+	invalid := fmt.Errorf("validation: invalid duration %q", s)
+	rest := s
+	sign := 1.0
+	if strings.HasPrefix(rest, "-") {
+		sign = -1
+		rest = rest[1:]
+	}
+	if !strings.HasPrefix(rest, "P") || len(rest) == 1 {
+		return 0, invalid
+	}
+	rest = rest[1:]
+	var total float64
+	inTime, hasTimeComponent := false, false
+	next := 0
+	for rest != "" {
+		if rest[0] == 'T' {
+			if inTime {
+				return 0, invalid
+			}
+			inTime = true
+			rest = rest[1:]
+			continue
+		}
+		end := strings.IndexFunc(rest, func(r rune) bool {
+			return (r < '0' || r > '9') && r != '.' && r != ','
+		})
+		if end <= 0 {
+			return 0, invalid
+		}
+		number, err := strconv.ParseFloat(strings.Replace(rest[:end], ",", ".", 1), 64)
+		if err != nil {
+			return 0, invalid
+		}
+		designator := rest[end]
+		rest = rest[end+1:]
+		if designator == 'Y' || (designator == 'M' && !inTime) {
+			return 0, fmt.Errorf("validation: duration %q has years or months, which have no fixed length", s)
+		}
+		found := false
+		for next < len(isoDurationUnits) {
+			unit := isoDurationUnits[next]
+			next++
+			if unit.designator == designator && unit.inTime == inTime {
+				total += number * float64(unit.unit)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return 0, invalid
+		}
+		hasTimeComponent = hasTimeComponent || inTime
+	}
+	if next == 0 || (inTime && !hasTimeComponent) || total > math.MaxInt64 {
+		return 0, invalid
+	}
+	return time.Duration(sign * total), nil
+}
+
+// MinDuration checks if the duration is at least min
+//
+// Has one parameter: min_duration (time.Duration)
+func MinDuration(d time.Duration, min time.Duration) Validator {
+	return func(field string) *FieldError {
+		if d < min {
+			msg := fmt.Sprintf("%s must be at least %s", field, min)
+			err := NewFieldError(field, msg, "min_duration", d)
+			err.SetParam("min_duration", min)
+			return err
+		}
+		return nil
+	}
+}
+
+// MaxDuration checks if the duration is at most max
+//
+// Has one parameter: max_duration (time.Duration)
+func MaxDuration(d time.Duration, max time.Duration) Validator {
+	return func(field string) *FieldError {
+		if d > max {
+			msg := fmt.Sprintf("%s must be at most %s", field, max)
+			err := NewFieldError(field, msg, "max_duration", d)
+			err.SetParam("max_duration", max)
+			return err
+		}
+		return nil
+	}
+}
+
+// TimeOfDay is a wall clock time, without a date or a timezone
+type TimeOfDay struct {
+	Hour   int
+	Minute int
+	Second int
+}
+
+// ParseTimeOfDay parses a 24-hour time of day as HH:MM or HH:MM:SS, e.g. "09:30" or "23:59:59"
+func ParseTimeOfDay(s string) (TimeOfDay, error) {
+	if (len(s) != 5 && len(s) != 8) || s[2] != ':' || (len(s) == 8 && s[5] != ':') {
+		return TimeOfDay{}, fmt.Errorf("validation: invalid time of day %q", s)
+	}
+	parts := []int{0, 0, 0}
+	for i := 0; i*3 < len(s); i++ {
+		if !isDigits(s[i*3:i*3+2], 2) {
+			return TimeOfDay{}, fmt.Errorf("validation: invalid time of day %q", s)
+		}
+		parts[i] = atoi2(s[i*3 : i*3+2])
+	}
+	t := TimeOfDay{parts[0], parts[1], parts[2]}
+	if t.Hour > 23 || t.Minute > 59 || t.Second > 59 {
+		return TimeOfDay{}, fmt.Errorf("validation: invalid time of day %q", s)
+	}
+	return t, nil
+}
+
+// TimeOfDayOf returns the wall clock time of t in its location
+func TimeOfDayOf(t time.Time) TimeOfDay {
+	return TimeOfDay{t.Hour(), t.Minute(), t.Second()}
+}
+
+// String formats the time of day as HH:MM:SS, e.g. "09:30:00"
+func (t TimeOfDay) String() string {
+	return fmt.Sprintf("%02d:%02d:%02d", t.Hour, t.Minute, t.Second)
+}
+
+// seconds returns the number of seconds since midnight
+func (t TimeOfDay) seconds() int {
+	return t.Hour*3600 + t.Minute*60 + t.Second
+}
+
+// IsTimeOfDay checks if the data is a 24-hour time of day as HH:MM or HH:MM:SS
+func IsTimeOfDay(value string) Validator {
+	return func(field string) *FieldError {
+		if _, err := ParseTimeOfDay(value); err != nil {
+			msg := fmt.Sprintf("%s is not a valid time of day", field)
+			return NewFieldError(field, msg, "is_time_of_day", value)
+		}
+		return nil
+	}
+}
+
+// TimeOfDayBetween checks if the time of day is between open and close, both included.
+// If close is before open, the range crosses midnight, e.g. from 22:00 to 06:00
+//
+// Has two parameters: open (string), close (string)
+func TimeOfDayBetween(value TimeOfDay, open TimeOfDay, close TimeOfDay) Validator {
+	return func(field string) *FieldError {
+		s := value.seconds()
+		var inRange bool
+		if open.seconds() <= close.seconds() {
+			inRange = s >= open.seconds() && s <= close.seconds()
+		} else {
+			inRange = s >= open.seconds() || s <= close.seconds()
+		}
+		if !inRange {
+			msg := fmt.Sprintf("%s must be between %s and %s", field, open, close)
+			err := NewFieldError(field, msg, "time_of_day_between", value)
+			err.SetParam("open", open.String())
+			err.SetParam("close", close.String())
+			return err
+		}
+		return nil
+	}
+}
+
+// getDuration returns the data as a time.Duration, parsing strings with ParseDuration
+// A nil *time.Duration is not an error, but the bool is false
+func (v *Builder) getDuration() (time.Duration, bool) {
+	switch val := v.value.(type) {
+	case time.Duration:
+		return val, true
+	case *time.Duration:
+		return ptrGet(val, 0)
+	default:
+		stringval, ok := v.getString()
+		if !ok {
+			return 0, false
+		}
+		if d, err := ParseDuration(stringval); err == nil {
+			return d, true
+		}
+	}
+	v.add("invalid duration", "invalid_duration")
+	return 0, false
+}
+
+// getTimeOfDay returns the data as a TimeOfDay, taking the wall clock time of a time.Time
+// and parsing strings with ParseTimeOfDay
+// A nil *TimeOfDay or *time.Time is not an error, but the bool is false
+func (v *Builder) getTimeOfDay() (TimeOfDay, bool) {
+	switch val := v.value.(type) {
+	case TimeOfDay:
+		return val, true
+	case *TimeOfDay:
+		return ptrGet(val, TimeOfDay{})
+	case time.Time:
+		return TimeOfDayOf(val), true
+	case *time.Time:
+		t, ok := ptrGet(val, time.Time{})
+		return TimeOfDayOf(t), ok
+	default:
+		stringval, ok := v.getString()
+		if !ok {
+			return TimeOfDay{}, false
+		}
+		if t, err := ParseTimeOfDay(stringval); err == nil {
+			return t, true
+		}
+	}
+	v.add("invalid time of day", "invalid_time_of_day")
+	return TimeOfDay{}, false
+}
+
+// MinDuration checks if the duration is at least min
+//
+// Has one parameter: min (time.Duration)
+func (v *Builder) MinDuration(min time.Duration) *Builder {
+	if v.hasError() {
+		return v
+	}
+	value, ok := v.getDuration()
+	if ok {
+		v.validation.Add(v.field, MinDuration(value, min))
+	}
+	return v
+}
+
+// MaxDuration checks if the duration is at most max
+//
+// Has one parameter: max (time.Duration)
+func (v *Builder) MaxDuration(max time.Duration) *Builder {
+	if v.hasError() {
+		return v
+	}
+	value, ok := v.getDuration()
+	if ok {
+		v.validation.Add(v.field, MaxDuration(value, max))
+	}
+	return v
+}
+
+// IsTimeOfDay checks if the data is a 24-hour time of day as HH:MM or HH:MM:SS
+func (v *Builder) IsTimeOfDay() *Builder {
+	if v.hasError() {
+		return v
+	}
+	value, ok := v.getString()
+	if ok {
+		v.validation.Add(v.field, IsTimeOfDay(value))
+	}
+	return v
+}
+
+// TimeOfDayBetween checks if the time of day is between open and close, which may cross midnight
+//
+// Has two parameters: open (TimeOfDay), close (TimeOfDay)
+func (v *Builder) TimeOfDayBetween(open, close TimeOfDay) *Builder {
+	if v.hasError() {
+		return v
+	}
+	value, ok := v.getTimeOfDay()
+	if ok {
+		v.validation.Add(v.field, TimeOfDayBetween(value, open, close))
+	}
+	return v
+}
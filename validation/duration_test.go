@@ -0,0 +1,139 @@
+package validation
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDuration(t *testing.T) {
+	testCases := []struct {
+		value    string
+		duration time.Duration
+	}{
+		{"15m", 15 * time.Minute},
+		{"1h30m", 90 * time.Minute},
+		{"-2s", -2 * time.Second},
+		{"PT15M", 15 * time.Minute},
+		{"PT1H30M", 90 * time.Minute},
+		{"P1DT12H", 36 * time.Hour},
+		{"P2W", 14 * 24 * time.Hour},
+		{"PT0.5S", 500 * time.Millisecond},
+		{"PT1,5S", 1500 * time.Millisecond},
+		{"-PT10S", -10 * time.Second},
+		{"P0D", 0},
+	}
+	for _, testCase := range testCases {
+		d, err := ParseDuration(testCase.value)
+		if err != nil {
+			t.Fatalf(`value %q is invalid, it should be valid: %v`, testCase.value, err)
+		}
+		if d != testCase.duration {
+			t.Fatalf(`value %q should be %s, got %s`, testCase.value, testCase.duration, d)
+		}
+	}
+
+	invalid := []string{"", "15", "P", "PT", "P1DT", "P1Y", "P1M", "PT1D", "P1H", "PT1M1H", "PT1H1H", "P1D1W", "PTM", "PT1X", "1 hour", "P1.2.3D"}
+	for _, value := range invalid {
+		if _, err := ParseDuration(value); err == nil {
+			t.Fatalf(`value %q is valid, it should be invalid`, value)
+		}
+	}
+}
+
+func TestMinMaxDuration(t *testing.T) {
+	if err := MinDuration(15*time.Minute, 15*time.Minute)("timeout"); err != nil {
+		t.Fatal("Expected error to be nil, got: ", err)
+	}
+	err := MinDuration(time.Minute, 15*time.Minute)("timeout")
+	if err == nil || err.Tag() != "min_duration" || err.Param("min_duration") != 15*time.Minute {
+		t.Fatalf("expected a min_duration error, got %v", err)
+	}
+	err = MaxDuration(2*time.Hour, time.Hour)("timeout")
+	if err == nil || err.Tag() != "max_duration" || err.Param("max_duration") != time.Hour {
+		t.Fatalf("expected a max_duration error, got %v", err)
+	}
+}
+
+func TestTimeOfDay(t *testing.T) {
+	valid := []string{"00:00", "09:30", "23:59", "23:59:59", "12:00:00"}
+	for _, value := range valid {
+		if err := IsTimeOfDay(value)("start"); err != nil {
+			t.Fatalf(`value %q is invalid, it should be valid`, value)
+		}
+	}
+	invalid := []string{"", "9:30", "24:00", "12:60", "12:00:60", "12:00:", "12-00", "1a:00", "12:00:00Z"}
+	for _, value := range invalid {
+		if err := IsTimeOfDay(value)("start"); err == nil || err.Tag() != "is_time_of_day" {
+			t.Fatalf(`value %q is valid, it should be invalid`, value)
+		}
+	}
+
+	at := func(s string) TimeOfDay {
+		t, _ := ParseTimeOfDay(s)
+		return t
+	}
+	testCases := []struct {
+		value, open, close string
+		valid              bool
+	}{
+		{"09:00", "09:00", "17:00", true},
+		{"17:00", "09:00", "17:00", true},
+		{"17:00:01", "09:00", "17:00", false},
+		{"08:59", "09:00", "17:00", false},
+		{"23:00", "22:00", "06:00", true},
+		{"00:00", "22:00", "06:00", true},
+		{"06:00", "22:00", "06:00", true},
+		{"12:00", "22:00", "06:00", false},
+		{"21:59", "22:00", "06:00", false},
+	}
+	for i, testCase := range testCases {
+		err := TimeOfDayBetween(at(testCase.value), at(testCase.open), at(testCase.close))("shift")
+		if testCase.valid && err != nil {
+			t.Fatalf(`case %d is invalid, it should be valid: %v`, i, err)
+		}
+		if !testCase.valid && (err == nil || err.Tag() != "time_of_day_between") {
+			t.Fatalf(`case %d is valid, it should be invalid`, i)
+		}
+	}
+
+	err := TimeOfDayBetween(at("12:00"), at("22:00"), at("06:00"))("shift")
+	if err.Param("open") != "22:00:00" || err.Param("close") != "06:00:00" {
+		t.Fatalf("expected the open and close params, got %v", err.Params())
+	}
+}
+
+func TestDurationBuilder(t *testing.T) {
+	timeout := 30 * time.Second
+	var missing *time.Duration
+	var missingTime *time.Time
+	v := New()
+	v.SetClock(FixedClock(time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC)))
+	v.Builder("timeout", timeout).MinDuration(time.Second).MaxDuration(time.Minute)
+	v.Builder("pointer", &timeout).MinDuration(time.Second)
+	v.Builder("missing", missing).MinDuration(time.Second)
+	v.Builder("iso", "PT15M").MinDuration(15 * time.Minute).MaxDuration(time.Hour)
+	v.Builder("go", "1h").Rules("min_duration:PT1H|max_duration:2h")
+	v.Builder("start", "23:30").IsTimeOfDay().TimeOfDayBetween(TimeOfDay{Hour: 22}, TimeOfDay{Hour: 6})
+	v.Builder("time", time.Date(2026, 10, 18, 5, 0, 0, 0, time.UTC)).TimeOfDayBetween(TimeOfDay{Hour: 22}, TimeOfDay{Hour: 6})
+	v.Builder("missing_time", missingTime).TimeOfDayBetween(TimeOfDay{Hour: 22}, TimeOfDay{Hour: 6})
+	v.Builder("rules", "01:00").Rules("is_time_of_day|time_of_day_between:22:00,06:00")
+	v.Builder("recent", "2026-10-18T09:00:00+07:00").Rules("within_last:P7D")
+	if err := v.Error(); err != nil {
+		t.Fatal("Expected error to be nil, got: ", err)
+	}
+
+	v = New()
+	v.Builder("timeout", "soon").MinDuration(time.Second)
+	v.Builder("start", "25:00").TimeOfDayBetween(TimeOfDay{Hour: 22}, TimeOfDay{Hour: 6})
+	errs := v.Error().(Error).Errors()
+	if errs["timeout"].Tag() != "invalid_duration" || errs["start"].Tag() != "invalid_time_of_day" {
+		t.Fatalf("expected invalid_duration and invalid_time_of_day, got %v", errs)
+	}
+
+	invalidRules := []string{"min_duration", "max_duration:P1M", "time_of_day_between:22:00", "time_of_day_between:22:00,6:00", "is_time_of_day:1"}
+	for _, rules := range invalidRules {
+		if _, err := CompileRuleSet(rules); err == nil {
+			t.Fatalf(`rule %q is valid, it should be invalid`, rules)
+		}
+	}
+}
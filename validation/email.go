@@ -0,0 +1,375 @@
+package validation
+
+import (
+	"bufio"
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/netip"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/net/idna"
+)
+
+// EmailLevel is how thoroughly Email checks an address, each level includes the previous ones
+type EmailLevel int
+
+const (
+	// EmailSyntax checks the address is an RFC 5322 addr-spec, so display names,
+	// angle brackets and comments are rejected. UTF-8 is allowed as in RFC 6532
+	EmailSyntax EmailLevel = iota
+	// EmailIDN converts the domain into its ASCII form, e.g. "bücher.example" into
+	// "xn--bcher-kva.example", and checks it is a valid host name
+	EmailIDN
+	// EmailDot requires a dot in the domain, so "user@localhost" is rejected
+	EmailDot
+	// EmailMX looks up the MX records of the domain, or its A and AAAA records if it has none
+	EmailMX
+)
+
+// emailTimeout bounds the MX lookups of Builder.Email
+const emailTimeout = 5 * time.Second
+
+var emailLevels = map[string]EmailLevel{
+	"syntax": EmailSyntax,
+	"idn":    EmailIDN,
+	"dot":    EmailDot,
+	"mx":     EmailMX,
+}
+
+// EmailResolver resolves the mail servers of a domain, it is implemented by *net.Resolver
+type EmailResolver interface {
+	Resolver
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+}
+
+// EmailConfig configures Email
+type EmailConfig struct {
+	// Level is how thoroughly the address is checked, the default is EmailSyntax
+	Level EmailLevel
+	// Resolver is used by EmailMX, the default is net.DefaultResolver
+	Resolver EmailResolver
+	// BlockDisposable rejects the domains of disposable email providers, see IsDisposableDomain
+	BlockDisposable bool
+}
+
+//go:embed disposable_domains.txt
+var disposableDomainsFile string
+
+var (
+	disposableMu      sync.RWMutex
+	disposableDomains = mustReadList(disposableDomainsFile)
+)
+
+// Email checks if the data is an email address, as thoroughly as configured
+//
+// Each check has its own tag: email_syntax, email_domain, email_dot, email_mx and
+// email_disposable. The domain checks have one parameter: domain (string), the ASCII
+// form of the domain
+func Email(ctx context.Context, email string, config EmailConfig) Validator {
+	return func(field string) *FieldError {
+		tag, domain := checkEmail(ctx, email, config)
+		if tag == "" {
+			return nil
+		}
+		var msg string
+		switch tag {
+		case "email_syntax":
+			msg = fmt.Sprintf("%s is not a valid email address", field)
+		case "email_disposable":
+			msg = fmt.Sprintf("%s must not be a disposable email address", field)
+		case "email_mx":
+			msg = fmt.Sprintf("%s has a domain that doesn't receive email", field)
+		default:
+			msg = fmt.Sprintf("%s has an invalid domain", field)
+		}
+		err := NewFieldError(field, msg, tag, email)
+		if domain != "" {
+			err.SetParam("domain", domain)
+		}
+		return err
+	}
+}
+
+// checkEmail returns the tag of the first check email fails and its domain,
+// or an empty tag if it passes every check
+func checkEmail(ctx context.Context, email string, config EmailConfig) (string, string) {
+	_, domain, ok := splitAddrSpec(email)
+	if !ok {
+		return "email_syntax", ""
+	}
+	literal := strings.HasPrefix(domain, "[")
+	if config.Level >= EmailIDN || config.BlockDisposable {
+		if literal {
+			return "email_domain", domain
+		}
+		ascii, err := domainToASCII(domain)
+		if err != nil || !isHostname(ascii) {
+			return "email_domain", domain
+		}
+		domain = ascii
+	}
+	if config.Level >= EmailDot && !strings.Contains(domain, ".") {
+		return "email_dot", domain
+	}
+	if config.BlockDisposable && IsDisposableDomain(domain) {
+		return "email_disposable", domain
+	}
+	if config.Level >= EmailMX && !hasMailServer(ctx, domain, config.Resolver) {
+		return "email_mx", domain
+	}
+	return "", domain
+}
+
+// hasMailServer reports whether domain has an MX record, or an A or AAAA record if it has no MX
+// record. A null MX record (RFC 7505) means the domain doesn't receive email
+func hasMailServer(ctx context.Context, domain string, resolver EmailResolver) bool {
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+	mxs, err := resolver.LookupMX(ctx, domain)
+	if err == nil && len(mxs) > 0 {
+		return !(len(mxs) == 1 && (mxs[0].Host == "." || mxs[0].Host == ""))
+	}
+	addrs, err := resolver.LookupIPAddr(ctx, domain)
+	return err == nil && len(addrs) > 0
+}
+
+// splitAddrSpec splits an RFC 5322 addr-spec into its local part and domain
+//
+// Folding white space and comments are not allowed
+func splitAddrSpec(email string) (string, string, bool) {
+	// the bytes from 0x80 are only allowed as the UTF-8 characters of RFC 6532,
+	// in atext and in quoted strings
+	if len(email) > 254 || !utf8.ValidString(email) {
+		return "", "", false
+	}
+	at := strings.LastIndexByte(email, '@')
+	if at < 0 {
+		return "", "", false
+	}
+	local, domain := email[:at], email[at+1:]
+	if len(local) > 64 || !isLocalPart(local) {
+		return "", "", false
+	}
+	if strings.HasPrefix(domain, "[") {
+		if !isDomainLiteral(domain) {
+			return "", "", false
+		}
+	} else if !isDotAtom(domain) {
+		return "", "", false
+	}
+	return local, domain, true
+}
+
+func isLocalPart(local string) bool {
+	if len(local) >= 2 && local[0] == '"' && local[len(local)-1] == '"' {
+		return isQuotedString(local[1 : len(local)-1])
+	}
+	return isDotAtom(local)
+}
+
+// isDotAtom checks if s is atoms of atext separated by single dots
+func isDotAtom(s string) bool {
+	if s == "" || s[0] == '.' || s[len(s)-1] == '.' || strings.Contains(s, "..") {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] != '.' && !isAtext(s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// isAtext checks if c is an atext character, or a byte of a UTF-8 character, which
+// splitAddrSpec checks are valid
+func isAtext(c byte) bool {
+	switch {
+	case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c >= 0x80:
+		return true
+	}
+	return strings.IndexByte("!#$%&'*+-/=?^_`{|}~", c) >= 0
+}
+
+// isQuotedString checks if s is the content of a quoted string, without the quotes
+func isQuotedString(s string) bool {
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '\\':
+			i++
+			if i == len(s) || (s[i] < ' ' && s[i] != '\t') || s[i] == 0x7f {
+				return false
+			}
+		case c == '"', c < ' ' && c != '\t', c == 0x7f:
+			return false
+		}
+	}
+	return true
+}
+
+// isDomainLiteral checks if s is an address literal, e.g. "[192.0.2.1]" or "[IPv6:2001:db8::1]"
+func isDomainLiteral(s string) bool {
+	if len(s) < 2 || s[len(s)-1] != ']' {
+		return false
+	}
+	literal := s[1 : len(s)-1]
+	if strings.HasPrefix(literal, "IPv6:") {
+		addr, err := netip.ParseAddr(literal[len("IPv6:"):])
+		return err == nil && addr.Is6() && addr.Zone() == ""
+	}
+	addr, err := netip.ParseAddr(literal)
+	return err == nil && addr.Is4()
+}
+
+// domainToASCII converts an internationalized domain name into its lower case ASCII form,
+// e.g. "Bücher.example" into "xn--bcher-kva.example", using the UTS #46 mapping for lookups
+func domainToASCII(domain string) (string, error) {
+	if !utf8.ValidString(domain) {
+		return "", errors.New("validation: invalid UTF-8 in domain")
+	}
+	return idna.Lookup.ToASCII(domain)
+}
+
+// NormalizeEmail trims the address and converts its domain into its lower case ASCII form,
+// e.g. " User@Bücher.Example " into "User@xn--bcher-kva.example"
+//
+// The local part is kept as is, since it may be case sensitive.
+// It reports false if the address is not valid at the EmailIDN level
+func NormalizeEmail(email string) (string, bool) {
+	local, domain, ok := splitAddrSpec(strings.TrimSpace(email))
+	if !ok || strings.HasPrefix(domain, "[") {
+		return email, false
+	}
+	ascii, err := domainToASCII(domain)
+	if err != nil || !isHostname(ascii) {
+		return email, false
+	}
+	return local + "@" + ascii, true
+}
+
+// IsDisposableDomain reports whether domain, or one of its parent domains,
+// belongs to a disposable email provider
+//
+// The list is embedded from disposable_domains.txt and can be changed with
+// SetDisposableDomains, AddDisposableDomains and LoadDisposableDomains
+func IsDisposableDomain(domain string) bool {
+	domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+	disposableMu.RLock()
+	defer disposableMu.RUnlock()
+	for {
+		if disposableDomains[domain] {
+			return true
+		}
+		dot := strings.IndexByte(domain, '.')
+		if dot < 0 {
+			return false
+		}
+		domain = domain[dot+1:]
+	}
+}
+
+// SetDisposableDomains replaces the list of disposable email domains
+func SetDisposableDomains(domains []string) {
+	set := make(map[string]bool, len(domains))
+	for _, domain := range domains {
+		set[strings.ToLower(domain)] = true
+	}
+	disposableMu.Lock()
+	defer disposableMu.Unlock()
+	disposableDomains = set
+}
+
+// AddDisposableDomains adds domains to the list of disposable email domains
+func AddDisposableDomains(domains ...string) {
+	disposableMu.Lock()
+	defer disposableMu.Unlock()
+	for _, domain := range domains {
+		disposableDomains[strings.ToLower(domain)] = true
+	}
+}
+
+// LoadDisposableDomains replaces the list of disposable email domains with the domains
+// read from r, one per line. Empty lines and lines starting with "#" are ignored
+func LoadDisposableDomains(r io.Reader) error {
+	domains, err := readList(r)
+	if err != nil {
+		return err
+	}
+	SetDisposableDomains(domains)
+	return nil
+}
+
+// readList reads the lines of r, ignoring empty lines and lines starting with "#"
+func readList(r io.Reader) ([]string, error) {
+	lines := make([]string, 0)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines, scanner.Err()
+}
+
+// mustReadList reads the lowercased lines of an embedded file into a set
+func mustReadList(file string) map[string]bool {
+	lines, err := readList(strings.NewReader(file))
+	if err != nil {
+		panic(err)
+	}
+	set := make(map[string]bool, len(lines))
+	for _, line := range lines {
+		set[strings.ToLower(line)] = true
+	}
+	return set
+}
+
+// Email checks if the data is an email address, as thoroughly as configured
+//
+// If config has no resolver, the resolver of the validation is used if it can look up MX records.
+// The MX lookups give up after 5 seconds, use EmailContext to control them
+func (v *Builder) Email(config EmailConfig) *Builder {
+	if config.Level < EmailMX {
+		return v.EmailContext(context.Background(), config)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), emailTimeout)
+	defer cancel()
+	return v.EmailContext(ctx, config)
+}
+
+// EmailContext is like Email but looks up the MX records with ctx,
+// which can cancel them or set a deadline
+func (v *Builder) EmailContext(ctx context.Context, config EmailConfig) *Builder {
+	if v.hasError() {
+		return v
+	}
+	if config.Resolver == nil {
+		if resolver, ok := v.validation.resolver.(EmailResolver); ok {
+			config.Resolver = resolver
+		}
+	}
+	value, ok := v.getString()
+	if ok {
+		v.validation.Add(v.field, Email(ctx, value, config))
+	}
+	return v
+}
+
+// NormalizeEmail trims the email address and converts its domain into its lower case ASCII form
+//
+// Invalid addresses are left untouched
+func (v *Builder) NormalizeEmail() *Builder {
+	return v.Transform(func(email string) string {
+		normalized, _ := NormalizeEmail(email)
+		return normalized
+	})
+}
@@ -0,0 +1,239 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// stubEmailResolver resolves MX records from a map, and IP addresses with stubResolver
+type stubEmailResolver struct {
+	stubResolver
+	mx map[string][]string
+}
+
+func (r stubEmailResolver) LookupMX(ctx context.Context, name string) ([]*net.MX, error) {
+	hosts, ok := r.mx[name]
+	if !ok {
+		return nil, errors.New("no such host")
+	}
+	mxs := make([]*net.MX, len(hosts))
+	for i, host := range hosts {
+		mxs[i] = &net.MX{Host: host, Pref: uint16(i)}
+	}
+	return mxs, nil
+}
+
+var testEmailResolver = stubEmailResolver{
+	stubResolver: stubResolver{"a-only.example": {"93.184.216.34"}},
+	mx: map[string][]string{
+		"example.com":           {"mx1.example.com.", "mx2.example.com."},
+		"xn--bcher-kva.example": {"mx.xn--bcher-kva.example."},
+		"null-mx.example":       {"."},
+	},
+}
+
+func TestEmailSyntax(t *testing.T) {
+	validValues := []string{
+		"user@example.com",
+		"first.last+tag@example.com",
+		"!#$%&'*+-/=?^_`{|}~@example.com",
+		`"john doe"@example.com`,
+		`"a\"b@c"@example.com`,
+		"user@localhost",
+		"user@[192.0.2.1]",
+		"user@[IPv6:2001:db8::1]",
+		"用户@例子.广告",
+	}
+	for _, value := range validValues {
+		v := New()
+		v.Add("test", Email(context.Background(), value, EmailConfig{}))
+		if v.Error() != nil {
+			t.Fatalf(`value %q is invalid, it should be valid`, value)
+		}
+	}
+	invalidValues := []string{
+		"",
+		"user",
+		"@example.com",
+		"user@",
+		"John <user@example.com>",
+		"<user@example.com>",
+		"user(comment)@example.com",
+		"user @example.com",
+		".user@example.com",
+		"user.@example.com",
+		"us..er@example.com",
+		"user@example..com",
+		"user@.example.com",
+		`"unterminated@example.com`,
+		"user@[300.0.0.1]",
+		"user@[2001:db8::1]",
+		strings.Repeat("a", 65) + "@example.com",
+		"user@" + strings.Repeat("a", 250) + ".com",
+		"us\xffer@example.com",
+		"\"us\xc3er\"@example.com",
+		"user@ex\x80ample.com",
+	}
+	for _, value := range invalidValues {
+		err := Email(context.Background(), value, EmailConfig{})("test")
+		if err == nil || err.Tag() != "email_syntax" {
+			t.Fatalf(`value %q should be invalid with email_syntax, got %v`, value, err)
+		}
+	}
+}
+
+func TestEmailLevels(t *testing.T) {
+	testCases := []struct {
+		value  string
+		config EmailConfig
+		tag    string
+		domain string
+	}{
+		{"user@bücher.example", EmailConfig{Level: EmailIDN}, "", ""},
+		{"user@exa_mple.com", EmailConfig{Level: EmailSyntax}, "", ""},
+		{"user@exa_mple.com", EmailConfig{Level: EmailIDN}, "email_domain", "exa_mple.com"},
+		{"user@-example.com", EmailConfig{Level: EmailIDN}, "email_domain", "-example.com"},
+		{"user@[192.0.2.1]", EmailConfig{Level: EmailIDN}, "email_domain", "[192.0.2.1]"},
+		{"user@localhost", EmailConfig{Level: EmailIDN}, "", ""},
+		{"user@localhost", EmailConfig{Level: EmailDot}, "email_dot", "localhost"},
+		{"user@example.com", EmailConfig{Level: EmailMX, Resolver: testEmailResolver}, "", ""},
+		{"user@BÜCHER.example", EmailConfig{Level: EmailMX, Resolver: testEmailResolver}, "", ""},
+		{"user@a-only.example", EmailConfig{Level: EmailMX, Resolver: testEmailResolver}, "", ""},
+		{"user@null-mx.example", EmailConfig{Level: EmailMX, Resolver: testEmailResolver}, "email_mx", "null-mx.example"},
+		{"user@nowhere.example", EmailConfig{Level: EmailMX, Resolver: testEmailResolver}, "email_mx", "nowhere.example"},
+		{"user@mailinator.com", EmailConfig{Level: EmailSyntax}, "", ""},
+		{"user@Mailinator.com", EmailConfig{BlockDisposable: true}, "email_disposable", "mailinator.com"},
+		{"user@eu.mailinator.com", EmailConfig{Level: EmailDot, BlockDisposable: true}, "email_disposable", "eu.mailinator.com"},
+		{"user@notmailinator.com", EmailConfig{Level: EmailDot, BlockDisposable: true}, "", ""},
+	}
+	for _, testCase := range testCases {
+		err := Email(context.Background(), testCase.value, testCase.config)("email")
+		if testCase.tag == "" && err != nil {
+			t.Fatalf(`value %q is invalid, it should be valid: %v`, testCase.value, err.Tag())
+		}
+		if testCase.tag != "" && (err == nil || err.Tag() != testCase.tag || err.Param("domain") != testCase.domain) {
+			t.Fatalf(`value %q should be invalid with %s (%s), got %v`, testCase.value, testCase.tag, testCase.domain, err)
+		}
+	}
+}
+
+func TestNormalizeEmail(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected string
+		ok       bool
+	}{
+		{" User@Example.COM ", "User@example.com", true},
+		{"user@Bücher.Example", "user@xn--bcher-kva.example", true},
+		{"John <user@example.com>", "John <user@example.com>", false},
+		{"user@[192.0.2.1]", "user@[192.0.2.1]", false},
+	}
+	for _, testCase := range testCases {
+		actual, ok := NormalizeEmail(testCase.value)
+		if actual != testCase.expected || ok != testCase.ok {
+			t.Fatalf(`value %q should be normalized into %q (%v), got %q (%v)`, testCase.value, testCase.expected, testCase.ok, actual, ok)
+		}
+	}
+}
+
+func TestDisposableDomains(t *testing.T) {
+	defer LoadDisposableDomains(strings.NewReader(disposableDomainsFile))
+
+	if !IsDisposableDomain("yopmail.com") || IsDisposableDomain("example.com") {
+		t.Fatal("expected only yopmail.com to be disposable")
+	}
+	AddDisposableDomains("Throwaway.Example")
+	if !IsDisposableDomain("mx.throwaway.example") {
+		t.Fatal("expected added domains to be disposable")
+	}
+	if err := LoadDisposableDomains(strings.NewReader("# comment\n\nonly.example\n")); err != nil {
+		t.Fatal("Expected error to be nil, got: ", err)
+	}
+	if !IsDisposableDomain("only.example") || IsDisposableDomain("yopmail.com") {
+		t.Fatal("expected the loaded list to replace the previous one")
+	}
+}
+
+func TestEmailBuilder(t *testing.T) {
+	v := New()
+	v.SetResolver(testEmailResolver)
+	var email string
+	v.Builder("email", " User@Bücher.Example ").
+		NormalizeEmail().
+		Email(EmailConfig{Level: EmailMX, BlockDisposable: true}).
+		IntoString(&email)
+	v.Builder("rules", "user@example.com").Rules("normalize_email|email:mx,disposable")
+	if err := v.Error(); err != nil {
+		t.Fatal("Expected error to be nil, got: ", err)
+	}
+	if email != "User@xn--bcher-kva.example" {
+		t.Fatalf(`expected normalized email, got %q`, email)
+	}
+
+	v = New()
+	v.Builder("email", "user@yopmail.com").Rules("email:dot,disposable")
+	if err := v.Error(); err == nil || err.(Error).Errors()["email"].Tag() != "email_disposable" {
+		t.Fatalf("expected email_disposable, got %v", err)
+	}
+	if _, err := CompileRuleSet("email:deliverable"); err == nil {
+		t.Fatal("Expected error to be not nil")
+	}
+}
+
+// blockingEmailResolver never answers, until the context is done
+type blockingEmailResolver struct {
+	blockingResolver
+}
+
+func (blockingEmailResolver) LookupMX(ctx context.Context, name string) ([]*net.MX, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestEmailContext(t *testing.T) {
+	v := New()
+	v.SetResolver(blockingEmailResolver{})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	v.Builder("email", "user@example.com").EmailContext(ctx, EmailConfig{Level: EmailMX})
+	if err := v.Error(); err == nil || err.(Error).Errors()["email"].Tag() != "email_mx" {
+		t.Fatalf("expected email_mx, got %v", err)
+	}
+}
+
+func TestDomainToASCII(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected string
+	}{
+		{"example.com", "example.com"},
+		{"Example.COM", "example.com"},
+		{"bücher.example", "xn--bcher-kva.example"},
+		{"MÜNCHEN.de", "xn--mnchen-3ya.de"},
+		{"例え.テスト", "xn--r8jz45g.xn--zckzah"},
+		{"例え。テスト", "xn--r8jz45g.xn--zckzah"},
+		{"пример.рф", "xn--e1afmkfd.xn--p1ai"},
+		// UTS #46 maps full width characters and normalizes to NFC
+		{"ｅｘａｍｐｌｅ.com", "example.com"},
+		{"bu\u0308cher.example", "xn--bcher-kva.example"},
+		{"faß.de", "xn--fa-hia.de"},
+	}
+	for _, testCase := range testCases {
+		actual, err := domainToASCII(testCase.value)
+		if err != nil {
+			t.Fatalf("value %q: Expected error to be nil, got: %v", testCase.value, err)
+		}
+		if actual != testCase.expected {
+			t.Fatalf(`value %q should be converted into %q, got %q`, testCase.value, testCase.expected, actual)
+		}
+	}
+	for _, value := range []string{"\xff.com", "a\u200db.com", "-bücher.example"} {
+		if _, err := domainToASCII(value); err == nil {
+			t.Fatalf("value %q: Expected error to be not nil", value)
+		}
+	}
+}
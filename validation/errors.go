@@ -17,9 +17,9 @@ type FieldError struct {
 	params  map[string]interface{}
 }
 
+// NewFieldError creates a new FieldError, the params are allocated on the first SetParam
 func NewFieldError(field, message, tag string, value interface{}) *FieldError {
-	params := make(map[string]interface{})
-	return &FieldError{field, message, tag, value, params}
+	return &FieldError{field, message, tag, value, nil}
 }
 
 func (e *FieldError) Field() string {
@@ -35,6 +35,9 @@ func (e *FieldError) SetMessage(message string) {
 }
 
 func (e *FieldError) SetParam(key string, value interface{}) {
+	if e.params == nil {
+		e.params = make(map[string]interface{})
+	}
 	e.params[key] = value
 }
 
@@ -42,6 +45,7 @@ func (e *FieldError) Param(key string) interface{} {
 	return e.params[key]
 }
 
+// Params returns the params of the error, which is nil if there is none
 func (e *FieldError) Params() map[string]interface{} {
 	return e.params
 }
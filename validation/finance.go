@@ -0,0 +1,319 @@
+package validation
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Card brands detected by CardBrand
+const (
+	CardVisa       = "visa"
+	CardMastercard = "mastercard"
+	CardAmex       = "amex"
+	CardDiscover   = "discover"
+	CardJCB        = "jcb"
+	CardDiners     = "diners"
+	CardUnionPay   = "unionpay"
+	CardMaestro    = "maestro"
+	CardUnknown    = "unknown"
+)
+
+// cardBrands are the prefixes and lengths of the card brands, in the order they are detected
+var cardBrands = []struct {
+	brand    string
+	prefixes [][2]int
+	lengths  [2]int
+}{
+	{CardAmex, [][2]int{{34, 34}, {37, 37}}, [2]int{15, 15}},
+	{CardVisa, [][2]int{{4, 4}}, [2]int{13, 19}},
+	{CardMastercard, [][2]int{{51, 55}, {2221, 2720}}, [2]int{16, 16}},
+	{CardDiscover, [][2]int{{6011, 6011}, {644, 649}, {65, 65}, {622126, 622925}}, [2]int{16, 19}},
+	{CardJCB, [][2]int{{3528, 3589}}, [2]int{16, 19}},
+	{CardDiners, [][2]int{{300, 305}, {36, 36}, {38, 39}}, [2]int{14, 19}},
+	{CardUnionPay, [][2]int{{62, 62}}, [2]int{16, 19}},
+	{CardMaestro, [][2]int{{50, 50}, {56, 69}}, [2]int{12, 19}},
+}
+
+// CardBrand returns the brand of a card number from its prefix, e.g. CardVisa for
+// "4111 1111 1111 1111", or CardUnknown. It doesn't check the length or the check digit
+func CardBrand(number string) string {
+	digits, ok := cardDigits(number)
+	if !ok {
+		return CardUnknown
+	}
+	for _, brand := range cardBrands {
+		for _, prefix := range brand.prefixes {
+			if hasNumericPrefix(digits, prefix[0], prefix[1]) {
+				return brand.brand
+			}
+		}
+	}
+	return CardUnknown
+}
+
+// hasNumericPrefix reports whether the number made of the first digits of s,
+// as many as there are in min, is between min and max
+func hasNumericPrefix(s string, min, max int) bool {
+	n := len(strconv.Itoa(min))
+	if len(s) < n {
+		return false
+	}
+	prefix, err := strconv.Atoi(s[:n])
+	return err == nil && prefix >= min && prefix <= max
+}
+
+// cardDigits removes the spaces and dashes of a card number, and reports false if it has other
+// characters than digits
+func cardDigits(number string) (string, bool) {
+	digits := strings.Map(func(r rune) rune {
+		if r == ' ' || r == '-' {
+			return -1
+		}
+		return r
+	}, number)
+	return digits, digits != "" && onlyDigitsRegex.MatchString(digits)
+}
+
+// IsCreditCard checks if the data is a card number with a valid Luhn check digit and a length
+// that is valid for its brand. Spaces and dashes are ignored.
+// If brands are given, the brand of the card must be one of them
+//
+// Has one parameter: brand (string), the detected brand, see CardBrand.
+// Cards of another brand have the tag card_brand, with another parameter: brands ([]string)
+func IsCreditCard(number string, brands ...string) Validator {
+	return func(field string) *FieldError {
+		digits, ok := cardDigits(number)
+		brand := CardBrand(digits)
+		if !ok || !Luhn.Valid(digits) || !isCardLength(brand, len(digits)) {
+			msg := fmt.Sprintf("%s is not a valid card number", field)
+			err := NewFieldError(field, msg, "is_credit_card", number)
+			err.SetParam("brand", brand)
+			return err
+		}
+		if len(brands) == 0 {
+			return nil
+		}
+		for _, allowed := range brands {
+			if brand == allowed {
+				return nil
+			}
+		}
+		msg := fmt.Sprintf("%s must be a %s card", field, strings.Join(brands, " or "))
+		err := NewFieldError(field, msg, "card_brand", number)
+		err.SetParam("brand", brand)
+		err.SetParam("brands", brands)
+		return err
+	}
+}
+
+func isCardLength(brand string, length int) bool {
+	for _, cardBrand := range cardBrands {
+		if cardBrand.brand == brand {
+			return length >= cardBrand.lengths[0] && length <= cardBrand.lengths[1]
+		}
+	}
+	return length >= 12 && length <= 19
+}
+
+// IsCardExpiry checks if the data is a card expiry date as MM/YY or MM/YYYY that is not in the
+// past according to clock, or the system clock if it is nil. A card expires after the last
+// day of its expiry month
+//
+// Has one parameter: reason (string), "format" or "expired"
+func IsCardExpiry(expiry string, clock Clock) Validator {
+	return func(field string) *FieldError {
+		reason := ""
+		month, year, ok := parseCardExpiry(expiry)
+		if !ok {
+			reason = "format"
+		} else {
+			current := now(clock)
+			if year < current.Year() || (year == current.Year() && time.Month(month) < current.Month()) {
+				reason = "expired"
+			}
+		}
+		if reason != "" {
+			msg := fmt.Sprintf("%s is not a valid expiry date", field)
+			err := NewFieldError(field, msg, "is_card_expiry", expiry)
+			err.SetParam("reason", reason)
+			return err
+		}
+		return nil
+	}
+}
+
+func parseCardExpiry(expiry string) (int, int, bool) {
+	monthPart, yearPart, ok := strings.Cut(expiry, "/")
+	if !ok {
+		return 0, 0, false
+	}
+	monthPart, yearPart = strings.TrimSpace(monthPart), strings.TrimSpace(yearPart)
+	if len(monthPart) != 2 || (len(yearPart) != 2 && len(yearPart) != 4) ||
+		!onlyDigitsRegex.MatchString(monthPart) || !onlyDigitsRegex.MatchString(yearPart) {
+		return 0, 0, false
+	}
+	month, _ := strconv.Atoi(monthPart)
+	year, _ := strconv.Atoi(yearPart)
+	if len(yearPart) == 2 {
+		year += 2000
+	}
+	return month, year, month >= 1 && month <= 12
+}
+
+// ibanLengths are the lengths of the IBANs of each country, from the SWIFT IBAN registry
+var ibanLengths = map[string]int{
+	"AD": 24, "AE": 23, "AL": 28, "AT": 20, "AZ": 28, "BA": 20, "BE": 16, "BG": 22,
+	"BH": 22, "BI": 27, "BR": 29, "BY": 28, "CH": 21, "CR": 22, "CY": 28, "CZ": 24,
+	"DE": 22, "DJ": 27, "DK": 18, "DO": 28, "EE": 20, "EG": 29, "ES": 24, "FI": 18,
+	"FK": 18, "FO": 18, "FR": 27, "GB": 22, "GE": 22, "GI": 23, "GL": 18, "GR": 27,
+	"GT": 28, "HR": 21, "HU": 28, "IE": 22, "IL": 23, "IQ": 23, "IS": 26, "IT": 27,
+	"JO": 30, "KW": 30, "KZ": 20, "LB": 28, "LC": 32, "LI": 21, "LT": 20, "LU": 20,
+	"LV": 21, "LY": 25, "MC": 27, "MD": 24, "ME": 22, "MK": 19, "MN": 20, "MR": 27,
+	"MT": 31, "MU": 30, "NI": 28, "NL": 18, "NO": 15, "OM": 23, "PK": 24, "PL": 28,
+	"PS": 29, "PT": 25, "QA": 29, "RO": 24, "RS": 22, "RU": 33, "SA": 24, "SC": 31,
+	"SD": 18, "SE": 24, "SI": 19, "SK": 24, "SM": 27, "SO": 23, "ST": 25, "SV": 28,
+	"TL": 23, "TN": 24, "TR": 26, "UA": 29, "VA": 22, "VG": 24, "XK": 20, "YE": 30,
+}
+
+// IsIBAN checks if the data is an International Bank Account Number with the length of its
+// country and valid check digits (ISO 7064 mod 97-10). Spaces are ignored
+//
+// Has one parameter: reason (string), one of "format", "country", "length" or "check_digits"
+func IsIBAN(value string) Validator {
+	return func(field string) *FieldError {
+		if reason := checkIBAN(value); reason != "" {
+			msg := fmt.Sprintf("%s is not a valid IBAN", field)
+			err := NewFieldError(field, msg, "is_iban", value)
+			err.SetParam("reason", reason)
+			return err
+		}
+		return nil
+	}
+}
+
+func checkIBAN(value string) string {
+	iban := strings.ReplaceAll(value, " ", "")
+	if len(iban) < 5 {
+		return "format"
+	}
+	for i := 0; i < len(iban); i++ {
+		c := iban[i]
+		if !(c >= 'A' && c <= 'Z' || c >= '0' && c <= '9') || (i < 2 && c <= '9') || (i >= 2 && i < 4 && c > '9') {
+			return "format"
+		}
+	}
+	length, ok := ibanLengths[iban[:2]]
+	if !ok {
+		return "country"
+	}
+	if len(iban) != length {
+		return "length"
+	}
+	// move the country code and check digits to the end
+	if !Mod97_10.Valid(iban[4:] + iban[:4]) {
+		return "check_digits"
+	}
+	return ""
+}
+
+// isoCountries are the ISO 3166-1 alpha-2 country codes
+var isoCountries = strings.Fields(`
+	AD AE AF AG AI AL AM AO AQ AR AS AT AU AW AX AZ BA BB BD BE BF BG BH BI BJ BL BM BN BO BQ
+	BR BS BT BV BW BY BZ CA CC CD CF CG CH CI CK CL CM CN CO CR CU CV CW CX CY CZ DE DJ DK DM
+	DO DZ EC EE EG EH ER ES ET FI FJ FK FM FO FR GA GB GD GE GF GG GH GI GL GM GN GP GQ GR GS
+	GT GU GW GY HK HM HN HR HT HU ID IE IL IM IN IO IQ IR IS IT JE JM JO JP KE KG KH KI KM KN
+	KP KR KW KY KZ LA LB LC LI LK LR LS LT LU LV LY MA MC MD ME MF MG MH MK ML MM MN MO MP MQ
+	MR MS MT MU MV MW MX MY MZ NA NC NE NF NG NI NL NO NP NR NU NZ OM PA PE PF PG PH PK PL PM
+	PN PR PS PT PW PY QA RE RO RS RU RW SA SB SC SD SE SG SH SI SJ SK SL SM SN SO SR SS ST SV
+	SX SY SZ TC TD TF TG TH TJ TK TL TM TN TO TR TT TV TW TZ UA UG UM US UY UZ VA VC VE VG VI
+	VN VU WF WS YE YT ZA ZM ZW XK
+`)
+
+func isISOCountry(code string) bool {
+	for _, country := range isoCountries {
+		if country == code {
+			return true
+		}
+	}
+	return false
+}
+
+// IsBIC checks if the data is a Business Identifier Code (SWIFT code), e.g. "DEUTDEFF" or
+// "DEUTDEFF500": a 4 letter institution code, an ISO 3166 country code, a 2 character
+// location code and an optional 3 character branch code
+func IsBIC(value string) Validator {
+	return func(field string) *FieldError {
+		if !isBIC(value) {
+			msg := fmt.Sprintf("%s is not a valid BIC", field)
+			return NewFieldError(field, msg, "is_bic", value)
+		}
+		return nil
+	}
+}
+
+func isBIC(value string) bool {
+	if len(value) != 8 && len(value) != 11 {
+		return false
+	}
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		letter := c >= 'A' && c <= 'Z'
+		if i < 6 && !letter || i >= 6 && !letter && !(c >= '0' && c <= '9') {
+			return false
+		}
+	}
+	return isISOCountry(value[4:6])
+}
+
+// IsCreditCard checks if the data is a card number with a valid Luhn check digit,
+// of one of the given brands if any
+func (v *Builder) IsCreditCard(brands ...string) *Builder {
+	if v.hasError() {
+		return v
+	}
+	value, ok := v.getString()
+	if ok {
+		v.validation.Add(v.field, IsCreditCard(value, brands...))
+	}
+	return v
+}
+
+// IsCardExpiry checks if the data is a card expiry date as MM/YY or MM/YYYY that is not in the past
+//
+// The current time is given by the clock of the validation, see Validation.SetClock
+func (v *Builder) IsCardExpiry() *Builder {
+	if v.hasError() {
+		return v
+	}
+	value, ok := v.getString()
+	if ok {
+		v.validation.Add(v.field, IsCardExpiry(value, v.validation.clock))
+	}
+	return v
+}
+
+// IsIBAN checks if the data is an International Bank Account Number
+func (v *Builder) IsIBAN() *Builder {
+	if v.hasError() {
+		return v
+	}
+	value, ok := v.getString()
+	if ok {
+		v.validation.Add(v.field, IsIBAN(value))
+	}
+	return v
+}
+
+// IsBIC checks if the data is a Business Identifier Code (SWIFT code)
+func (v *Builder) IsBIC() *Builder {
+	if v.hasError() {
+		return v
+	}
+	value, ok := v.getString()
+	if ok {
+		v.validation.Add(v.field, IsBIC(value))
+	}
+	return v
+}
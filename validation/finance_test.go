@@ -0,0 +1,155 @@
+package validation
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCardBrand(t *testing.T) {
+	testCases := []struct {
+		number string
+		brand  string
+	}{
+		{"4111 1111 1111 1111", CardVisa},
+		{"5555-5555-5555-4444", CardMastercard},
+		{"2223003122003222", CardMastercard},
+		{"378282246310005", CardAmex},
+		{"6011111111111117", CardDiscover},
+		{"6221260000000000", CardDiscover},
+		{"3530111333300000", CardJCB},
+		{"30569309025904", CardDiners},
+		{"6200000000000005", CardUnionPay},
+		{"6759649826438453", CardMaestro},
+		{"1234567890123456", CardUnknown},
+		{"not a card", CardUnknown},
+	}
+	for _, testCase := range testCases {
+		if brand := CardBrand(testCase.number); brand != testCase.brand {
+			t.Fatalf(`value %q should be a %s card, got %s`, testCase.number, testCase.brand, brand)
+		}
+	}
+}
+
+func TestIsCreditCard(t *testing.T) {
+	testCases := []struct {
+		validator Validator
+		tag       string
+	}{
+		{IsCreditCard("4111 1111 1111 1111"), ""},
+		{IsCreditCard("378282246310005", CardVisa, CardAmex), ""},
+		{IsCreditCard("4111111111111112"), "is_credit_card"},
+		{IsCreditCard("4111-1111-1111-111a"), "is_credit_card"},
+		{IsCreditCard("37828224631000"), "is_credit_card"},
+		{IsCreditCard(""), "is_credit_card"},
+		{IsCreditCard("5555555555554444", CardVisa), "card_brand"},
+	}
+	for i, testCase := range testCases {
+		err := testCase.validator("card")
+		if testCase.tag == "" && err != nil {
+			t.Fatalf(`case %d is invalid, it should be valid: %v`, i, err)
+		}
+		if testCase.tag != "" && (err == nil || err.Tag() != testCase.tag) {
+			t.Fatalf(`case %d should be invalid with %s, got %v`, i, testCase.tag, err)
+		}
+	}
+	err := IsCreditCard("5555555555554444", CardVisa)("card")
+	if err.Param("brand") != CardMastercard {
+		t.Fatalf("expected the brand param to be mastercard, got %v", err.Params())
+	}
+}
+
+func TestIsCardExpiry(t *testing.T) {
+	clock := FixedClock(time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC))
+	testCases := []struct {
+		expiry string
+		reason string
+	}{
+		{"10/26", ""},
+		{"01/27", ""},
+		{"12/2030", ""},
+		{"10 / 26", ""},
+		{"09/26", "expired"},
+		{"12/25", "expired"},
+		{"13/26", "format"},
+		{"00/26", "format"},
+		{"1/26", "format"},
+		{"10-26", "format"},
+		{"10/026", "format"},
+	}
+	for _, testCase := range testCases {
+		err := IsCardExpiry(testCase.expiry, clock)("expiry")
+		if testCase.reason == "" && err != nil {
+			t.Fatalf(`value %q is invalid, it should be valid: %v`, testCase.expiry, err.Params())
+		}
+		if testCase.reason != "" && (err == nil || err.Param("reason") != testCase.reason) {
+			t.Fatalf(`value %q should be invalid with reason %q, got %v`, testCase.expiry, testCase.reason, err)
+		}
+	}
+}
+
+func TestIsIBAN(t *testing.T) {
+	testCases := []struct {
+		value  string
+		reason string
+	}{
+		{"GB82 WEST 1234 5698 7654 32", ""},
+		{"DE89370400440532013000", ""},
+		{"NL91ABNA0417164300", ""},
+		{"NO9386011117947", ""},
+		{"GB82WEST12345698765431", "check_digits"},
+		{"GB82WEST1234569876543", "length"},
+		{"ZZ82WEST12345698765432", "country"},
+		{"gb82west12345698765432", "format"},
+		{"GBXXWEST12345698765432", "format"},
+		{"GB8", "format"},
+	}
+	for _, testCase := range testCases {
+		err := IsIBAN(testCase.value)("iban")
+		if testCase.reason == "" && err != nil {
+			t.Fatalf(`value %q is invalid, it should be valid: %v`, testCase.value, err.Params())
+		}
+		if testCase.reason != "" && (err == nil || err.Param("reason") != testCase.reason) {
+			t.Fatalf(`value %q should be invalid with reason %q, got %v`, testCase.value, testCase.reason, err)
+		}
+	}
+}
+
+func TestIsBIC(t *testing.T) {
+	validValues := []string{"DEUTDEFF", "DEUTDEFF500", "NEDSZAJJXXX", "BNINIDJA"}
+	for _, value := range validValues {
+		v := New()
+		v.Add("test", IsBIC(value))
+		if v.Error() != nil {
+			t.Fatalf(`value %q is invalid, it should be valid`, value)
+		}
+	}
+	invalidValues := []string{"DEUTDEF", "DEUTDEFF50", "deutdeff", "DEUTZZFF", "DEU1DEFF", "DEUTDEF#"}
+	for _, value := range invalidValues {
+		v := New()
+		v.Add("test", IsBIC(value))
+		if v.Error() == nil {
+			t.Fatalf(`value %q is valid, it should be invalid`, value)
+		}
+	}
+}
+
+func TestFinanceBuilder(t *testing.T) {
+	v := New()
+	v.SetClock(FixedClock(time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)))
+	v.Builder("card", "4111 1111 1111 1111").IsCreditCard(CardVisa, CardMastercard)
+	v.Builder("expiry", "10/26").IsCardExpiry()
+	v.Builder("iban", "DE89370400440532013000").IsIBAN()
+	v.Builder("bic", "DEUTDEFF").IsBIC()
+	v.Builder("rules", "5555555555554444").Rules("is_credit_card:visa,mastercard")
+	if err := v.Error(); err != nil {
+		t.Fatal("Expected error to be nil, got: ", err)
+	}
+
+	v = New()
+	v.SetClock(FixedClock(time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)))
+	v.Builder("expiry", "09/26").Rules("is_card_expiry")
+	v.Builder("card", "378282246310005").Rules("is_credit_card:visa")
+	if err := v.Error(); err == nil || len(err.(Error).Errors()) != 2 {
+		t.Fatalf("expected 2 errors, got %v", err)
+	}
+}
@@ -2,6 +2,9 @@ package validation
 
 import (
 	"fmt"
+	"math"
+	"math/big"
+	"reflect"
 	"strconv"
 	"time"
 )
@@ -13,41 +16,32 @@ func (v *Builder) add(message, tag string) {
 
 // getTime parses a time.Time from a string or time.Time and returns the time.Time and a bool indicating if the parsing was successful
 // If the parsing was not successful, the time.Time will be the zero value
-// If the value is a string, it will attempt to parse it using the following layouts (in order):
-// - time.RFC3339
-// - "2006-01-02"
-// - "2006-01-02 15:04:05"
-// - "2006-01-02T15:04:05Z"
-// - "2006-01-02T15:04:05"
-// - "01/02/2006"
-// - "01-02-2006"
-// - "01/02/2006 15:04:05"
-// - "01-02-2006 15:04:05"
+// A nil *time.Time is not an error, but the bool is false
+// If the value is a string, it is parsed with the layout set by DateFormat, or else with the layouts
+// of the validation, see Validation.SetDateLayouts and DefaultDateLayouts
 func (v *Builder) getTime() (time.Time, bool) {
+	return v.getTimeIn(nil)
+}
+
+// getTimeIn is like getTime, but strings without a timezone are parsed in loc when no
+// location is set on the validation, e.g. in the timezone of the clock
+func (v *Builder) getTimeIn(loc *time.Location) (time.Time, bool) {
 	switch val := v.value.(type) {
 	case time.Time:
 		return val, true
+	case *time.Time:
+		return ptrGet(val, time.Time{})
 	default:
 		stringval, ok := v.getString()
 		if !ok {
-			v.add("invalid time", "invalid_time")
 			return time.Time{}, false
 		}
-		parsingLayouts := []string{
-			time.RFC3339,
-			"2006-01-02",
-			"2006-01-02 15:04:05",
-			"2006-01-02T15:04:05Z",
-			"2006-01-02T15:04:05",
-			"01/02/2006",
-			"01-02-2006",
-			"01/02/2006 15:04:05",
-			"01-02-2006 15:04:05",
-		}
-		for _, layout := range parsingLayouts {
-			if t, err := time.Parse(layout, stringval); err == nil {
-				return t, true
-			}
+		layouts := v.validation.dateLayouts()
+		if v.dateLayout != "" {
+			layouts = []string{v.dateLayout}
+		}
+		if t, ok := v.validation.parseTime(stringval, layouts, loc); ok {
+			return t, true
 		}
 	}
 	v.add("invalid time", "invalid_time")
@@ -63,10 +57,30 @@ func ptrGet[T any](v *T, def T) (T, bool) {
 	return *v, true
 }
 
+// getLengthMode returns the length mode of the builder, or the one of the validation if it is not set
+func (v *Builder) getLengthMode() LengthMode {
+	if v.lengthMode != 0 {
+		return v.lengthMode
+	}
+	return v.validation.lengthMode
+}
+
 func (v *Builder) hasError() bool {
 	return v.validation.fieldErrors[v.field] != nil
 }
 
+// isSet reports whether the value is neither nil nor a nil pointer
+func (v *Builder) isSet() bool {
+	if v.value == nil {
+		return false
+	}
+	rv := reflect.ValueOf(v.value)
+	return rv.Kind() != reflect.Ptr || !rv.IsNil()
+}
+
+// getString returns the data as a string, formatting the other types with fmt
+// A nil value or a nil pointer is not an error, but the bool is false, and the getters
+// built on it skip the validators the same way
 func (v *Builder) getString() (string, bool) {
 	var value string
 	switch val := v.value.(type) {
@@ -78,6 +92,9 @@ func (v *Builder) getString() (string, bool) {
 		}
 		value = *val
 	default:
+		if !v.isSet() {
+			return "", false
+		}
 		value = fmt.Sprintf("%v", v.value)
 	}
 	return value, true
@@ -111,6 +128,15 @@ func (v *Builder) getInt() (int64, bool) {
 	case *int64:
 		ptrval, ok := ptrGet(val, 0)
 		return int64(ptrval), ok
+	case *big.Int:
+		if val == nil {
+			return 0, false
+		}
+		if !val.IsInt64() {
+			v.add("Invalid integer", "invalid_integer")
+			return 0, false
+		}
+		return val.Int64(), true
 	case uint, uint8, uint16, uint32, uint64, *uint, *uint8, *uint16, *uint32, *uint64:
 		uintval, ok := v.getUint()
 		if !ok {
@@ -126,7 +152,6 @@ func (v *Builder) getInt() (int64, bool) {
 	default:
 		stringval, ok := v.getString()
 		if !ok {
-			v.add("Invalid integer", "invalid_integer")
 			return 0, false
 		}
 		value, err := strconv.ParseInt(stringval, 10, 64)
@@ -167,16 +192,38 @@ func (v *Builder) getUint() (uint64, bool) {
 	case *uint64:
 		ptrval, ok := ptrGet(val, 0)
 		return ptrval, ok
+	case *big.Int:
+		if val == nil {
+			return 0, false
+		}
+		if !val.IsUint64() {
+			v.add("Invalid integer", "invalid_integer")
+			return 0, false
+		}
+		return val.Uint64(), true
 	case int, int8, int16, int32, int64, *int, *int8, *int16, *int32, *int64:
 		intval, ok := v.getInt()
-		return uint64(intval), ok
+		if !ok {
+			return 0, false
+		}
+		if intval < 0 {
+			v.add("Invalid integer", "invalid_integer")
+			return 0, false
+		}
+		return uint64(intval), true
 	case float32, float64, *float32, *float64:
 		floatval, ok := v.getFloat()
-		return uint64(floatval), ok
+		if !ok {
+			return 0, false
+		}
+		if floatval < 0 {
+			v.add("Invalid integer", "invalid_integer")
+			return 0, false
+		}
+		return uint64(floatval), true
 	default:
 		stringval, ok := v.getString()
 		if !ok {
-			v.add("Invalid integer", "invalid_integer")
 			return 0, false
 		}
 		value, err := strconv.ParseUint(stringval, 10, 64)
@@ -202,6 +249,36 @@ func (v *Builder) getFloat() (float64, bool) {
 	case *float64:
 		ptrval, ok := ptrGet(val, 0)
 		return ptrval, ok
+	case *big.Int:
+		if val == nil {
+			return 0, false
+		}
+		value, accuracy := new(big.Float).SetInt(val).Float64()
+		if accuracy != big.Exact {
+			v.add("Invalid float", "invalid_float")
+			return 0, false
+		}
+		return value, true
+	case *big.Rat:
+		if val == nil {
+			return 0, false
+		}
+		value, ok := ratFloat64(val)
+		if !ok {
+			v.add("Invalid float", "invalid_float")
+			return 0, false
+		}
+		return value, true
+	case *big.Float:
+		if val == nil {
+			return 0, false
+		}
+		value, accuracy := val.Float64()
+		if accuracy != big.Exact {
+			v.add("Invalid float", "invalid_float")
+			return 0, false
+		}
+		return value, true
 	case int, int8, int16, int32, int64, *int, *int8, *int16, *int32, *int64:
 		intval, ok := v.getInt()
 		return float64(intval), ok
@@ -211,7 +288,6 @@ func (v *Builder) getFloat() (float64, bool) {
 	default:
 		stringval, ok := v.getString()
 		if !ok {
-			v.add("Invalid float", "invalid_float")
 			return 0, false
 		}
 		value, err := strconv.ParseFloat(stringval, 64)
@@ -223,3 +299,18 @@ func (v *Builder) getFloat() (float64, bool) {
 	}
 	return value, true
 }
+
+// ratFloat64 converts r into a float64, and reports false if precision is lost. Like a
+// parsed string, a fraction such as 0.1 is accepted when the float is its closest value,
+// which is when the shortest decimal form of the float is exactly r
+func ratFloat64(r *big.Rat) (float64, bool) {
+	value, exact := r.Float64()
+	if exact {
+		return value, true
+	}
+	if math.IsInf(value, 0) {
+		return 0, false
+	}
+	decimal, ok := new(big.Rat).SetString(strconv.FormatFloat(value, 'g', -1, 64))
+	return value, ok && decimal.Cmp(r) == 0
+}
@@ -0,0 +1,299 @@
+package validation
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// indonesianProvinces are the province codes used by NIK and No. KK
+var indonesianProvinces = map[string]string{
+	"11": "Aceh",
+	"12": "Sumatera Utara",
+	"13": "Sumatera Barat",
+	"14": "Riau",
+	"15": "Jambi",
+	"16": "Sumatera Selatan",
+	"17": "Bengkulu",
+	"18": "Lampung",
+	"19": "Kepulauan Bangka Belitung",
+	"21": "Kepulauan Riau",
+	"31": "DKI Jakarta",
+	"32": "Jawa Barat",
+	"33": "Jawa Tengah",
+	"34": "DI Yogyakarta",
+	"35": "Jawa Timur",
+	"36": "Banten",
+	"51": "Bali",
+	"52": "Nusa Tenggara Barat",
+	"53": "Nusa Tenggara Timur",
+	"61": "Kalimantan Barat",
+	"62": "Kalimantan Tengah",
+	"63": "Kalimantan Selatan",
+	"64": "Kalimantan Timur",
+	"65": "Kalimantan Utara",
+	"71": "Sulawesi Utara",
+	"72": "Sulawesi Tengah",
+	"73": "Sulawesi Selatan",
+	"74": "Sulawesi Tenggara",
+	"75": "Gorontalo",
+	"76": "Sulawesi Barat",
+	"81": "Maluku",
+	"82": "Maluku Utara",
+	"91": "Papua",
+	"92": "Papua Barat",
+	"93": "Papua Selatan",
+	"94": "Papua Tengah",
+	"95": "Papua Pegunungan",
+	"96": "Papua Barat Daya",
+}
+
+// NIK is a parsed Nomor Induk Kependudukan, the Indonesian identity number
+type NIK struct {
+	// ProvinceCode, RegencyCode and DistrictCode are the codes of the region where the
+	// number was registered, e.g. "32", "73" and "01"
+	ProvinceCode string
+	RegencyCode  string
+	DistrictCode string
+	// BirthDay, BirthMonth and BirthYear are the birth date, BirthYear only has two digits
+	BirthDay   int
+	BirthMonth int
+	BirthYear  int
+	// Female is encoded by adding 40 to the birth day
+	Female bool
+	Serial string
+}
+
+// Province returns the name of the province, e.g. "Jawa Barat"
+func (n NIK) Province() string {
+	return indonesianProvinces[n.ProvinceCode]
+}
+
+// Reasons of the "reason" param of is_nik, is_no_kk and is_npwp
+const (
+	IndonesianIDFormat     = "format"
+	IndonesianIDProvince   = "province"
+	IndonesianIDRegency    = "regency"
+	IndonesianIDDistrict   = "district"
+	IndonesianIDDate       = "date"
+	IndonesianIDSerial     = "serial"
+	IndonesianIDCheckDigit = "check_digit"
+)
+
+// ParseNIK parses a 16 digit NIK, e.g. "3273014509900001": the province, regency and
+// district codes, the birth date as DDMMYY with 40 added to the day for women,
+// and a serial number
+func ParseNIK(value string) (NIK, error) {
+	nik, reason := parseNIK(value)
+	if reason != "" {
+		return NIK{}, fmt.Errorf("validation: invalid NIK %s", reason)
+	}
+	return nik, nil
+}
+
+// parseNIK parses a NIK, or returns the reason why it is invalid
+func parseNIK(value string) (NIK, string) {
+	if len(value) != 16 || !onlyDigitsRegex.MatchString(value) {
+		return NIK{}, IndonesianIDFormat
+	}
+	if reason := checkIndonesianRegion(value[:6]); reason != "" {
+		return NIK{}, reason
+	}
+	day, month, year := atoi2(value[6:8]), atoi2(value[8:10]), atoi2(value[10:12])
+	female := day > 40
+	if female {
+		day -= 40
+	}
+	if !isValidDayMonth(day, month) {
+		return NIK{}, IndonesianIDDate
+	}
+	if value[12:] == "0000" {
+		return NIK{}, IndonesianIDSerial
+	}
+	return NIK{
+		ProvinceCode: value[:2],
+		RegencyCode:  value[2:4],
+		DistrictCode: value[4:6],
+		BirthDay:     day,
+		BirthMonth:   month,
+		BirthYear:    year,
+		Female:       female,
+		Serial:       value[12:],
+	}, ""
+}
+
+// checkIndonesianRegion checks the province, regency and district codes of region
+func checkIndonesianRegion(region string) string {
+	if _, ok := indonesianProvinces[region[:2]]; !ok {
+		return IndonesianIDProvince
+	}
+	if region[2:4] == "00" {
+		return IndonesianIDRegency
+	}
+	if region[4:6] == "00" {
+		return IndonesianIDDistrict
+	}
+	return ""
+}
+
+// isValidDayMonth checks day exists in month, allowing February 29 since the year is ambiguous
+func isValidDayMonth(day, month int) bool {
+	daysInMonth := [...]int{31, 29, 31, 30, 31, 30, 31, 31, 30, 31, 30, 31}
+	return month >= 1 && month <= 12 && day >= 1 && day <= daysInMonth[month-1]
+}
+
+func atoi2(s string) int {
+	return int(s[0]-'0')*10 + int(s[1]-'0')
+}
+
+// IsNIK checks if the data is a valid NIK (Nomor Induk Kependudukan), the Indonesian identity number
+//
+// Has one parameter: reason (string), one of the IndonesianID constants
+func IsNIK(value string) Validator {
+	return func(field string) *FieldError {
+		if _, reason := parseNIK(value); reason != "" {
+			msg := fmt.Sprintf("%s is not a valid NIK", field)
+			err := NewFieldError(field, msg, "is_nik", value)
+			err.SetParam("reason", reason)
+			return err
+		}
+		return nil
+	}
+}
+
+// IsNoKK checks if the data is a valid Nomor Kartu Keluarga, the Indonesian family card number
+//
+// It has the same region codes as a NIK, followed by the issue date as DDMMYY and a serial number.
+// Has one parameter: reason (string), one of the IndonesianID constants
+func IsNoKK(value string) Validator {
+	return func(field string) *FieldError {
+		if reason := checkNoKK(value); reason != "" {
+			msg := fmt.Sprintf("%s is not a valid family card number", field)
+			err := NewFieldError(field, msg, "is_no_kk", value)
+			err.SetParam("reason", reason)
+			return err
+		}
+		return nil
+	}
+}
+
+func checkNoKK(value string) string {
+	if len(value) != 16 || !onlyDigitsRegex.MatchString(value) {
+		return IndonesianIDFormat
+	}
+	if reason := checkIndonesianRegion(value[:6]); reason != "" {
+		return reason
+	}
+	if !isValidDayMonth(atoi2(value[6:8]), atoi2(value[8:10])) {
+		return IndonesianIDDate
+	}
+	if value[12:] == "0000" {
+		return IndonesianIDSerial
+	}
+	return ""
+}
+
+// IsNPWP checks if the data is a valid NPWP (Nomor Pokok Wajib Pajak), the Indonesian tax number
+//
+// The 15 digit format, e.g. "09.254.294.3-407.000", has a Luhn check digit as its 9th digit.
+// The 16 digit format is either a NIK, or a 15 digit NPWP prefixed with 0.
+// Dots, dashes and spaces are ignored.
+// Has one parameter: reason (string), one of the IndonesianID constants
+func IsNPWP(value string) Validator {
+	return func(field string) *FieldError {
+		if reason := checkNPWP(value); reason != "" {
+			msg := fmt.Sprintf("%s is not a valid NPWP", field)
+			err := NewFieldError(field, msg, "is_npwp", value)
+			err.SetParam("reason", reason)
+			return err
+		}
+		return nil
+	}
+}
+
+func checkNPWP(value string) string {
+	digits := strings.Map(func(r rune) rune {
+		switch r {
+		case '.', '-', ' ':
+			return -1
+		}
+		return r
+	}, value)
+	if !onlyDigitsRegex.MatchString(digits) {
+		return IndonesianIDFormat
+	}
+	switch {
+	case len(digits) == 16 && digits[0] == '0':
+		digits = digits[1:]
+	case len(digits) == 16:
+		_, reason := parseNIK(digits)
+		return reason
+	case len(digits) != 15:
+		return IndonesianIDFormat
+	}
+	if !Luhn.Valid(digits[:9]) {
+		return IndonesianIDCheckDigit
+	}
+	return ""
+}
+
+// IsKodePos checks if the data is an Indonesian postal code (kode pos), five digits
+// between 10110 and 99976
+func IsKodePos(value string) Validator {
+	return func(field string) *FieldError {
+		code, err := strconv.Atoi(value)
+		if len(value) != 5 || err != nil || code < 10110 || code > 99976 {
+			msg := fmt.Sprintf("%s is not a valid postal code", field)
+			return NewFieldError(field, msg, "is_kode_pos", value)
+		}
+		return nil
+	}
+}
+
+// IsNIK checks if the data is a valid NIK (Nomor Induk Kependudukan), the Indonesian identity number
+func (v *Builder) IsNIK() *Builder {
+	if v.hasError() {
+		return v
+	}
+	value, ok := v.getString()
+	if ok {
+		v.validation.Add(v.field, IsNIK(value))
+	}
+	return v
+}
+
+// IsNoKK checks if the data is a valid Nomor Kartu Keluarga, the Indonesian family card number
+func (v *Builder) IsNoKK() *Builder {
+	if v.hasError() {
+		return v
+	}
+	value, ok := v.getString()
+	if ok {
+		v.validation.Add(v.field, IsNoKK(value))
+	}
+	return v
+}
+
+// IsNPWP checks if the data is a valid NPWP (Nomor Pokok Wajib Pajak), the Indonesian tax number
+func (v *Builder) IsNPWP() *Builder {
+	if v.hasError() {
+		return v
+	}
+	value, ok := v.getString()
+	if ok {
+		v.validation.Add(v.field, IsNPWP(value))
+	}
+	return v
+}
+
+// IsKodePos checks if the data is an Indonesian postal code (kode pos)
+func (v *Builder) IsKodePos() *Builder {
+	if v.hasError() {
+		return v
+	}
+	value, ok := v.getString()
+	if ok {
+		v.validation.Add(v.field, IsKodePos(value))
+	}
+	return v
+}
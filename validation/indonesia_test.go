@@ -0,0 +1,89 @@
+package validation
+
+import "testing"
+
+func TestParseNIK(t *testing.T) {
+	nik, err := ParseNIK("3273014509900001")
+	if err != nil {
+		t.Fatal("Expected error to be nil, got: ", err)
+	}
+	if nik.Province() != "Jawa Barat" || nik.RegencyCode != "73" || nik.DistrictCode != "01" {
+		t.Fatalf("unexpected region %+v", nik)
+	}
+	if nik.BirthDay != 5 || nik.BirthMonth != 9 || nik.BirthYear != 90 || !nik.Female || nik.Serial != "0001" {
+		t.Fatalf("unexpected birth date %+v", nik)
+	}
+	if _, err := ParseNIK("3273010509900000"); err == nil {
+		t.Fatal("Expected error to be not nil")
+	}
+}
+
+func TestIndonesianValidators(t *testing.T) {
+	testCases := []struct {
+		validator Validator
+		reason    string
+	}{
+		{IsNIK("3273010509900001"), ""},
+		{IsNIK("3273014509900001"), ""},
+		{IsNIK("9601012902000123"), ""},
+		{IsNIK("327301050990001"), IndonesianIDFormat},
+		{IsNIK("32730105099000a1"), IndonesianIDFormat},
+		{IsNIK("2073010509900001"), IndonesianIDProvince},
+		{IsNIK("3200010509900001"), IndonesianIDRegency},
+		{IsNIK("3273000509900001"), IndonesianIDDistrict},
+		{IsNIK("3273013209900001"), IndonesianIDDate},
+		{IsNIK("3273017209900001"), IndonesianIDDate},
+		{IsNIK("3273013102900001"), IndonesianIDDate},
+		{IsNIK("3273010513900001"), IndonesianIDDate},
+		{IsNIK("3273010509900000"), IndonesianIDSerial},
+		{IsNoKK("3273011203150007"), ""},
+		{IsNoKK("3273015203150007"), IndonesianIDDate},
+		{IsNoKK("9973011203150007"), IndonesianIDProvince},
+		{IsNoKK("3273011203150000"), IndonesianIDSerial},
+		{IsNPWP("09.254.294.3-407.000"), ""},
+		{IsNPWP("092542943407000"), ""},
+		{IsNPWP("0092542943407000"), ""},
+		{IsNPWP("3273014509900001"), ""},
+		{IsNPWP("09.254.294.4-407.000"), IndonesianIDCheckDigit},
+		{IsNPWP("3273017209900001"), IndonesianIDDate},
+		{IsNPWP("09.254.294.3-407.00"), IndonesianIDFormat},
+		{IsNPWP("09/254/294/3/407/000"), IndonesianIDFormat},
+		{IsKodePos("40111"), ""},
+		{IsKodePos("10110"), ""},
+		{IsKodePos("99976"), ""},
+		{IsKodePos("01234"), "invalid"},
+		{IsKodePos("4011"), "invalid"},
+		{IsKodePos("+4011"), "invalid"},
+	}
+	for i, testCase := range testCases {
+		err := testCase.validator("test")
+		if testCase.reason == "" && err != nil {
+			t.Fatalf(`case %d is invalid, it should be valid: %v`, i, err.Params())
+		}
+		if testCase.reason != "" && err == nil {
+			t.Fatalf(`case %d is valid, it should be invalid`, i)
+		}
+		if testCase.reason != "" && err.Tag() != "is_kode_pos" && err.Param("reason") != testCase.reason {
+			t.Fatalf(`case %d should be invalid with reason %q, got %v`, i, testCase.reason, err.Params())
+		}
+	}
+}
+
+func TestIndonesianBuilder(t *testing.T) {
+	v := New()
+	v.Builder("nik", "3273014509900001").IsNIK()
+	v.Builder("no_kk", "3273011203150007").IsNoKK()
+	v.Builder("npwp", "09.254.294.3-407.000").IsNPWP()
+	v.Builder("kode_pos", 40111).IsKodePos()
+	v.Builder("rules", "3273014509900001").Rules("is_nik|is_npwp")
+	if err := v.Error(); err != nil {
+		t.Fatal("Expected error to be nil, got: ", err)
+	}
+
+	v = New()
+	v.Builder("nik", "3273014509900000").IsNIK()
+	v.Builder("kode_pos", "00000").Rules("is_kode_pos")
+	if err := v.Error(); err == nil || len(err.(Error).Errors()) != 2 {
+		t.Fatalf("expected 2 errors, got %v", err)
+	}
+}
@@ -0,0 +1,190 @@
+package validation
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Interval is a range from Start to End, e.g. a booking. Start and End are numbers,
+// see NoOverlap and NoGaps, or times, see NoOverlapDates and NoGapsDates
+type Interval[T any] struct {
+	Start T
+	End   T
+}
+
+// RangePair checks if start is not after end, and if the span from start to end is at least
+// minSpan and at most maxSpan. A zero minSpan or maxSpan is not checked
+//
+// A start after end has the tag range_order. A span too short or too long has the tag
+// range_min_span or range_max_span, with two parameters: span and min_span or max_span
+// (same type as start)
+func RangePair[T Number](start, end, minSpan, maxSpan T) Validator {
+	return func(field string) *FieldError {
+		if start > end {
+			return rangeOrderError(field, Interval[T]{start, end})
+		}
+		return checkSpan(field, Interval[T]{start, end}, end-start, minSpan, maxSpan)
+	}
+}
+
+// DateRangePair checks if start is not after end, and if the time from start to end is at least
+// minSpan and at most maxSpan. A zero minSpan or maxSpan is not checked
+//
+// A start after end has the tag range_order. A span too short or too long has the tag
+// range_min_span or range_max_span, with two parameters: span and min_span or max_span
+// (time.Duration)
+func DateRangePair(start, end time.Time, minSpan, maxSpan time.Duration) Validator {
+	return func(field string) *FieldError {
+		if start.After(end) {
+			return rangeOrderError(field, Interval[time.Time]{start, end})
+		}
+		return checkSpan(field, Interval[time.Time]{start, end}, end.Sub(start), minSpan, maxSpan)
+	}
+}
+
+func rangeOrderError(field string, value interface{}) *FieldError {
+	msg := fmt.Sprintf("%s must start before it ends", field)
+	return NewFieldError(field, msg, "range_order", value)
+}
+
+// checkSpan checks span is between minSpan and maxSpan, when they are not zero
+func checkSpan[S Number](field string, value interface{}, span, minSpan, maxSpan S) *FieldError {
+	if minSpan != 0 && span < minSpan {
+		msg := fmt.Sprintf("%s must span at least %v", field, minSpan)
+		err := NewFieldError(field, msg, "range_min_span", value)
+		err.SetParam("span", span)
+		err.SetParam("min_span", minSpan)
+		return err
+	}
+	if maxSpan != 0 && span > maxSpan {
+		msg := fmt.Sprintf("%s must span at most %v", field, maxSpan)
+		err := NewFieldError(field, msg, "range_max_span", value)
+		err.SetParam("span", span)
+		err.SetParam("max_span", maxSpan)
+		return err
+	}
+	return nil
+}
+
+// NoOverlap checks if the intervals don't overlap each other, intervals that touch,
+// like 1 to 2 and 2 to 3, don't overlap
+//
+// Intervals that end before they start have the tag intervals_order, with one parameter:
+// indices ([]int). Overlapping intervals have the tag intervals_overlap, with one parameter:
+// indices ([][2]int), the pairs of indices of the overlapping intervals
+func NoOverlap[T Number](intervals []Interval[T]) Validator {
+	return noOverlap(intervals, func(a, b T) bool { return a < b })
+}
+
+// NoOverlapDates checks if the time intervals don't overlap each other, see NoOverlap
+func NoOverlapDates(intervals []Interval[time.Time]) Validator {
+	return noOverlap(intervals, time.Time.Before)
+}
+
+// NoGaps checks if the intervals leave no gap between the start of the first one and
+// the end of the last one
+//
+// Intervals that end before they start have the tag intervals_order, with one parameter:
+// indices ([]int). Gaps have the tag intervals_gap, with one parameter: indices ([][2]int),
+// the pairs of indices of the intervals before and after each gap
+func NoGaps[T Number](intervals []Interval[T]) Validator {
+	return noGaps(intervals, func(a, b T) bool { return a < b })
+}
+
+// NoGapsDates checks if the time intervals leave no gap between them, see NoGaps
+func NoGapsDates(intervals []Interval[time.Time]) Validator {
+	return noGaps(intervals, time.Time.Before)
+}
+
+// sortedIntervals returns the indices of intervals sorted by start, or the intervals_order
+// error if some of them end before they start
+func sortedIntervals[T any](field string, intervals []Interval[T], less func(a, b T) bool) ([]int, *FieldError) {
+	var unordered []int
+	order := make([]int, len(intervals))
+	for i, interval := range intervals {
+		if less(interval.End, interval.Start) {
+			unordered = append(unordered, i)
+		}
+		order[i] = i
+	}
+	if len(unordered) > 0 {
+		msg := fmt.Sprintf("%s must start before they end", field)
+		err := NewFieldError(field, msg, "intervals_order", intervals)
+		err.SetParam("indices", unordered)
+		return nil, err
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return less(intervals[order[i]].Start, intervals[order[j]].Start)
+	})
+	return order, nil
+}
+
+func noOverlap[T any](intervals []Interval[T], less func(a, b T) bool) Validator {
+	return func(field string) *FieldError {
+		order, err := sortedIntervals(field, intervals, less)
+		if err != nil {
+			return err
+		}
+		var overlaps [][2]int
+		for i, a := range order {
+			for _, b := range order[i+1:] {
+				// b starts after a, so they overlap if b starts before a ends
+				if !less(intervals[b].Start, intervals[a].End) {
+					break
+				}
+				overlaps = append(overlaps, orderedPair(a, b))
+			}
+		}
+		if len(overlaps) > 0 {
+			sortPairs(overlaps)
+			msg := fmt.Sprintf("%s must not overlap", field)
+			err := NewFieldError(field, msg, "intervals_overlap", intervals)
+			err.SetParam("indices", overlaps)
+			return err
+		}
+		return nil
+	}
+}
+
+func noGaps[T any](intervals []Interval[T], less func(a, b T) bool) Validator {
+	return func(field string) *FieldError {
+		order, err := sortedIntervals(field, intervals, less)
+		if err != nil || len(order) == 0 {
+			return err
+		}
+		var gaps [][2]int
+		last := order[0]
+		for _, i := range order[1:] {
+			if less(intervals[last].End, intervals[i].Start) {
+				gaps = append(gaps, [2]int{last, i})
+			}
+			if less(intervals[last].End, intervals[i].End) {
+				last = i
+			}
+		}
+		if len(gaps) > 0 {
+			msg := fmt.Sprintf("%s must not have gaps", field)
+			err := NewFieldError(field, msg, "intervals_gap", intervals)
+			err.SetParam("indices", gaps)
+			return err
+		}
+		return nil
+	}
+}
+
+func orderedPair(a, b int) [2]int {
+	if a > b {
+		return [2]int{b, a}
+	}
+	return [2]int{a, b}
+}
+
+func sortPairs(pairs [][2]int) {
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i][0] != pairs[j][0] {
+			return pairs[i][0] < pairs[j][0]
+		}
+		return pairs[i][1] < pairs[j][1]
+	})
+}
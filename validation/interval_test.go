@@ -0,0 +1,107 @@
+package validation
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRangePair(t *testing.T) {
+	testCases := []struct {
+		validator Validator
+		tag       string
+	}{
+		{RangePair(1, 5, 0, 0), ""},
+		{RangePair(5, 5, 0, 0), ""},
+		{RangePair(5, 1, 0, 0), "range_order"},
+		{RangePair(1.5, 3.5, 1.0, 2.0), ""},
+		{RangePair(1.5, 2.0, 1.0, 2.0), "range_min_span"},
+		{RangePair(uint(1), uint(10), 0, 5), "range_max_span"},
+		{RangePair(uint(10), uint(1), 0, 5), "range_order"},
+		{DateRangePair(day(1), day(3), 24*time.Hour, 7*24*time.Hour), ""},
+		{DateRangePair(day(3), day(1), 0, 0), "range_order"},
+		{DateRangePair(day(1), day(1).Add(time.Hour), 24*time.Hour, 0), "range_min_span"},
+		{DateRangePair(day(1), day(10), 0, 7*24*time.Hour), "range_max_span"},
+	}
+	for i, testCase := range testCases {
+		err := testCase.validator("booking")
+		if testCase.tag == "" && err != nil {
+			t.Fatalf(`case %d is invalid, it should be valid: %v`, i, err)
+		}
+		if testCase.tag != "" && (err == nil || err.Tag() != testCase.tag) {
+			t.Fatalf(`case %d should be invalid with %s, got %v`, i, testCase.tag, err)
+		}
+	}
+
+	err := DateRangePair(day(1), day(10), 0, 7*24*time.Hour)("booking")
+	if err.Param("span") != 9*24*time.Hour || err.Param("max_span") != 7*24*time.Hour {
+		t.Fatalf("expected the span and max_span params, got %v", err.Params())
+	}
+}
+
+func day(n int) time.Time {
+	return time.Date(2026, 10, n, 0, 0, 0, 0, time.UTC)
+}
+
+func TestNoOverlap(t *testing.T) {
+	testCases := []struct {
+		intervals []Interval[int]
+		tag       string
+		indices   interface{}
+	}{
+		{nil, "", nil},
+		{[]Interval[int]{{1, 2}, {2, 3}, {5, 6}}, "", nil},
+		{[]Interval[int]{{5, 6}, {1, 3}, {2, 4}}, "intervals_overlap", [][2]int{{1, 2}}},
+		{[]Interval[int]{{1, 10}, {2, 3}, {4, 5}, {9, 12}}, "intervals_overlap", [][2]int{{0, 1}, {0, 2}, {0, 3}}},
+		{[]Interval[int]{{1, 2}, {4, 3}, {6, 5}}, "intervals_order", []int{1, 2}},
+	}
+	for i, testCase := range testCases {
+		err := NoOverlap(testCase.intervals)("bookings")
+		if testCase.tag == "" && err != nil {
+			t.Fatalf(`case %d is invalid, it should be valid: %v`, i, err)
+		}
+		if testCase.tag == "" {
+			continue
+		}
+		if err == nil || err.Tag() != testCase.tag || !reflect.DeepEqual(err.Param("indices"), testCase.indices) {
+			t.Fatalf(`case %d should be invalid with %s %v, got %v`, i, testCase.tag, testCase.indices, err)
+		}
+	}
+
+	rooms := []Interval[time.Time]{{day(1), day(3)}, {day(3), day(5)}, {day(4), day(6)}}
+	err := NoOverlapDates(rooms)("bookings")
+	if err == nil || !reflect.DeepEqual(err.Param("indices"), [][2]int{{1, 2}}) {
+		t.Fatalf("expected bookings 1 and 2 to overlap, got %v", err)
+	}
+}
+
+func TestNoGaps(t *testing.T) {
+	testCases := []struct {
+		intervals []Interval[float64]
+		indices   [][2]int
+	}{
+		{nil, nil},
+		{[]Interval[float64]{{0, 8}, {8, 16}, {16, 24}}, nil},
+		{[]Interval[float64]{{16, 24}, {0, 10}, {8, 16}}, nil},
+		{[]Interval[float64]{{0, 20}, {5, 6}, {19, 24}}, nil},
+		{[]Interval[float64]{{0, 8}, {9, 16}, {16.5, 24}}, [][2]int{{0, 1}, {1, 2}}},
+		{[]Interval[float64]{{0, 20}, {5, 6}, {21, 24}}, [][2]int{{0, 2}}},
+	}
+	for i, testCase := range testCases {
+		err := NoGaps(testCase.intervals)("shifts")
+		if testCase.indices == nil && err != nil {
+			t.Fatalf(`case %d is invalid, it should be valid: %v`, i, err)
+		}
+		if testCase.indices != nil && (err == nil || err.Tag() != "intervals_gap" || !reflect.DeepEqual(err.Param("indices"), testCase.indices)) {
+			t.Fatalf(`case %d should have gaps %v, got %v`, i, testCase.indices, err)
+		}
+	}
+
+	shifts := []Interval[time.Time]{{day(1), day(2)}, {day(3), day(4)}}
+	if err := NoGapsDates(shifts)("shifts"); err == nil || err.Tag() != "intervals_gap" {
+		t.Fatalf("expected a gap between the shifts, got %v", err)
+	}
+	if err := NoGapsDates([]Interval[time.Time]{{day(2), day(1)}})("shifts"); err == nil || err.Tag() != "intervals_order" {
+		t.Fatalf("expected an intervals_order error, got %v", err)
+	}
+}
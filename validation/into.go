@@ -0,0 +1,167 @@
+package validation
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Into writes the coerced value into dst if the field has no error
+//
+// dst must be a pointer to a string, an integer, a float or a time.Time.
+// The value is coerced the same way the other builder methods coerce it,
+// an integer that does not fit into dst is reported as invalid_integer and
+// a float that overflows a float32 is reported as invalid_float.
+// Nil values are not written
+func (v *Builder) Into(dst interface{}) *Builder {
+	switch dst := dst.(type) {
+	case *string:
+		return v.IntoString(dst)
+	case *int:
+		return intoSigned(v, dst, math.MinInt, math.MaxInt)
+	case *int8:
+		return intoSigned(v, dst, math.MinInt8, math.MaxInt8)
+	case *int16:
+		return intoSigned(v, dst, math.MinInt16, math.MaxInt16)
+	case *int32:
+		return intoSigned(v, dst, math.MinInt32, math.MaxInt32)
+	case *int64:
+		return v.IntoInt64(dst)
+	case *uint:
+		return intoUnsigned(v, dst, math.MaxUint)
+	case *uint8:
+		return intoUnsigned(v, dst, math.MaxUint8)
+	case *uint16:
+		return intoUnsigned(v, dst, math.MaxUint16)
+	case *uint32:
+		return intoUnsigned(v, dst, math.MaxUint32)
+	case *uint64:
+		return v.IntoUint64(dst)
+	case *float32:
+		return intoFloat32(v, dst)
+	case *float64:
+		return v.IntoFloat64(dst)
+	case *time.Time:
+		return v.IntoTime(dst)
+	default:
+		panic(fmt.Sprintf("validation: unsupported destination type %T", dst))
+	}
+}
+
+// IntoString writes the value into dst if the field has no error
+//
+// Nil values are not written
+func (v *Builder) IntoString(dst *string) *Builder {
+	if v.hasError() || !v.isSet() {
+		return v
+	}
+	value, ok := v.getString()
+	if ok {
+		*dst = value
+	}
+	return v
+}
+
+// IntoInt64 writes the value coerced into an int64 into dst if the field has no error
+//
+// Nil values are not written
+func (v *Builder) IntoInt64(dst *int64) *Builder {
+	if v.hasError() || !v.isSet() {
+		return v
+	}
+	value, ok := v.getInt()
+	if ok {
+		*dst = value
+	}
+	return v
+}
+
+// IntoUint64 writes the value coerced into an uint64 into dst if the field has no error
+//
+// Nil values are not written
+func (v *Builder) IntoUint64(dst *uint64) *Builder {
+	if v.hasError() || !v.isSet() {
+		return v
+	}
+	value, ok := v.getUint()
+	if ok {
+		*dst = value
+	}
+	return v
+}
+
+// IntoFloat64 writes the value coerced into a float64 into dst if the field has no error
+//
+// Nil values are not written
+func (v *Builder) IntoFloat64(dst *float64) *Builder {
+	if v.hasError() || !v.isSet() {
+		return v
+	}
+	value, ok := v.getFloat()
+	if ok {
+		*dst = value
+	}
+	return v
+}
+
+// IntoTime writes the value coerced into a time.Time into dst if the field has no error
+//
+// Nil values are not written
+func (v *Builder) IntoTime(dst *time.Time) *Builder {
+	if v.hasError() || !v.isSet() {
+		return v
+	}
+	value, ok := v.getTime()
+	if ok {
+		*dst = value
+	}
+	return v
+}
+
+func intoSigned[T Signed](v *Builder, dst *T, min, max int64) *Builder {
+	if v.hasError() || !v.isSet() {
+		return v
+	}
+	value, ok := v.getInt()
+	if !ok {
+		return v
+	}
+	if value < min || value > max {
+		v.add("Invalid integer", "invalid_integer")
+		return v
+	}
+	*dst = T(value)
+	return v
+}
+
+func intoUnsigned[T Unsigned](v *Builder, dst *T, max uint64) *Builder {
+	if v.hasError() || !v.isSet() {
+		return v
+	}
+	value, ok := v.getUint()
+	if !ok {
+		return v
+	}
+	if value > max {
+		v.add("Invalid integer", "invalid_integer")
+		return v
+	}
+	*dst = T(value)
+	return v
+}
+
+func intoFloat32(v *Builder, dst *float32) *Builder {
+	if v.hasError() || !v.isSet() {
+		return v
+	}
+	value, ok := v.getFloat()
+	if !ok {
+		return v
+	}
+	if !math.IsInf(value, 0) && math.Abs(value) > math.MaxFloat32 {
+		v.add("Invalid float", "invalid_float")
+		return v
+	}
+	*dst = float32(value)
+	return v
+}
@@ -0,0 +1,114 @@
+package validation
+
+import (
+	"testing"
+	"time"
+)
+
+func TestInto(t *testing.T) {
+	v := New()
+
+	var i int
+	var i8 int8
+	var i64 int64
+	var u uint
+	var u64 uint64
+	var f32 float32
+	var f64 float64
+	var s string
+	var tm time.Time
+
+	v.Builder("int", "42").MinInt(1).Into(&i)
+	v.Builder("int8", "-8").Into(&i8)
+	v.Builder("int64", ptr("64")).IntoInt64(&i64)
+	v.Builder("uint", "7").Into(&u)
+	v.Builder("uint64", 64).IntoUint64(&u64)
+	v.Builder("float32", "1.5").Into(&f32)
+	v.Builder("float64", "2.5").MinFloat(1).IntoFloat64(&f64)
+	v.Builder("string", 12).Into(&s)
+	v.Builder("time", "2009-12-12").IntoTime(&tm)
+
+	if err := v.Error(); err != nil {
+		t.Fatal("Expected error to be nil, got: ", err)
+	}
+	if i != 42 || i8 != -8 || i64 != 64 || u != 7 || u64 != 64 || f32 != 1.5 || f64 != 2.5 || s != "12" {
+		t.Fatalf("unexpected values: %v %v %v %v %v %v %v %q", i, i8, i64, u, u64, f32, f64, s)
+	}
+	if !tm.Equal(time.Date(2009, 12, 12, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected time: %v", tm)
+	}
+}
+
+func TestIntoInvalid(t *testing.T) {
+	testCases := []struct {
+		value interface{}
+		build func(b *Builder)
+	}{
+		{"abc", func(b *Builder) { var dst int; b.Into(&dst) }},
+		{"300", func(b *Builder) { var dst int8; b.Into(&dst) }},
+		{"70000", func(b *Builder) { var dst uint16; b.Into(&dst) }},
+		{-1, func(b *Builder) { var dst uint; b.Into(&dst) }},
+		{int8(-1), func(b *Builder) { var dst uint64; b.IntoUint64(&dst) }},
+		{-1.5, func(b *Builder) { var dst uint64; b.Into(&dst) }},
+		{"abc", func(b *Builder) { var dst float64; b.Into(&dst) }},
+		{"1e39", func(b *Builder) { var dst float32; b.Into(&dst) }},
+		{-1e39, func(b *Builder) { var dst float32; b.Into(&dst) }},
+		{"abc", func(b *Builder) { var dst time.Time; b.Into(&dst) }},
+	}
+	for _, testCase := range testCases {
+		v := New()
+		testCase.build(v.Builder("test", testCase.value))
+		if v.Error() == nil {
+			t.Fatalf(`value %q is valid, it should be invalid`, testCase.value)
+		}
+	}
+}
+
+func TestIntoNotWrittenOnError(t *testing.T) {
+	v := New()
+	dst := int64(-1)
+	v.Builder("test", "3").MinInt(5).IntoInt64(&dst)
+	if dst != -1 {
+		t.Fatalf("destination should not be written when the field has an error, got %d", dst)
+	}
+}
+
+func TestIntoNil(t *testing.T) {
+	v := New()
+	var value *string
+	dst := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	v.Builder("test", value).IntoTime(&dst)
+	if v.Error() != nil {
+		t.Fatal("Expected error to be nil, got: ", v.Error())
+	}
+	if dst.Year() != 2000 {
+		t.Fatal("nil value should not be written")
+	}
+
+	s := "untouched"
+	i64 := int64(-1)
+	u64 := uint64(1)
+	f64 := 1.5
+	var i8 int8 = -1
+	v.Builder("string", nil).IntoString(&s)
+	v.Builder("int64", nil).IntoInt64(&i64)
+	v.Builder("uint64", (*uint64)(nil)).IntoUint64(&u64)
+	v.Builder("float64", nil).IntoFloat64(&f64)
+	v.Builder("int8", nil).Into(&i8)
+	if v.Error() != nil {
+		t.Fatal("Expected error to be nil, got: ", v.Error())
+	}
+	if s != "untouched" || i64 != -1 || u64 != 1 || f64 != 1.5 || i8 != -1 {
+		t.Fatalf("nil values should not be written: %q %d %d %v %d", s, i64, u64, f64, i8)
+	}
+}
+
+func TestIntoUnsupported(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected a panic for an unsupported destination")
+		}
+	}()
+	var dst []string
+	New().Builder("test", "test").Into(&dst)
+}
@@ -0,0 +1,450 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mfaizudd/nodebat-go/validation"
+)
+
+// Dialect is the flavor of the generated schema
+type Dialect int
+
+const (
+	// Draft202012 generates a JSON Schema (draft 2020-12) document
+	Draft202012 Dialect = iota
+	// OpenAPI3 generates an OpenAPI 3.0 schema object
+	OpenAPI3
+)
+
+// Draft202012URI is the $schema of the documents generated with Draft202012
+const Draft202012URI = "https://json-schema.org/draft/2020-12/schema"
+
+// RuleMapper adds the keywords describing rule to schema
+type RuleMapper func(rule validation.Rule, schema map[string]interface{})
+
+var (
+	mappersMu sync.RWMutex
+	mappers   = map[string]RuleMapper{}
+)
+
+// RegisterRule sets the mapper used to describe the rule named name
+//
+// Rules without a mapper are left out of the generated schema
+func RegisterRule(name string, mapper RuleMapper) {
+	mappersMu.Lock()
+	defer mappersMu.Unlock()
+	mappers[name] = mapper
+}
+
+func lookupMapper(name string) (RuleMapper, bool) {
+	mappersMu.RLock()
+	defer mappersMu.RUnlock()
+	mapper, ok := mappers[name]
+	return mapper, ok
+}
+
+// FromRules generates a schema from rule strings keyed by path, see validation.MapRules
+//
+// The type of each value is inferred from its rules
+func FromRules(rules map[string]string, dialect Dialect) (map[string]interface{}, error) {
+	root := newNode()
+	for path, rule := range rules {
+		parsed, err := validation.ParseRules(rule)
+		if err != nil {
+			return nil, fmt.Errorf("jsonschema: %s: %w", path, err)
+		}
+		root.at(strings.Split(path, ".")).rules = parsed
+	}
+	return root.schema(dialect, true), nil
+}
+
+// FromMapRules generates a schema from compiled map rules
+func FromMapRules(rules *validation.MapRules, dialect Dialect) map[string]interface{} {
+	root := newNode()
+	for path, set := range rules.Rules() {
+		root.at(strings.Split(path, ".")).rules = set.Rules()
+	}
+	return root.schema(dialect, true)
+}
+
+// FromSchema generates a schema from the fields of a validation.Schema declared with rule
+// strings, see validation.Schema.Rules
+//
+// The type of each value is inferred from its rules
+func FromSchema[T any](schema *validation.Schema[T], dialect Dialect) map[string]interface{} {
+	root := newNode()
+	for path, set := range schema.Rules() {
+		root.at(strings.Split(path, ".")).rules = set.Rules()
+	}
+	return root.schema(dialect, true)
+}
+
+// FromStruct generates a schema from the validate tags of a struct, see validation.StructRules
+//
+// The type of each value is taken from the Go type of its field.
+// v can be a struct, a pointer to a struct or a reflect.Type
+func FromStruct(v interface{}, dialect Dialect) (map[string]interface{}, error) {
+	t, ok := v.(reflect.Type)
+	if !ok {
+		t = reflect.TypeOf(v)
+	}
+	rules, err := validation.StructRules(t)
+	if err != nil {
+		return nil, err
+	}
+	root := newNode()
+	root.typed(t, map[reflect.Type]bool{})
+	for path, rule := range rules {
+		parsed, err := validation.ParseRules(rule)
+		if err != nil {
+			return nil, fmt.Errorf("jsonschema: %s: %w", path, err)
+		}
+		root.at(strings.Split(path, ".")).rules = parsed
+	}
+	return root.schema(dialect, true), nil
+}
+
+// node is a value of the generated schema
+type node struct {
+	typ        string
+	format     string
+	nullable   bool
+	rules      []validation.Rule
+	properties map[string]*node
+	items      *node
+}
+
+func newNode() *node {
+	return &node{properties: map[string]*node{}}
+}
+
+// at returns the node at path, creating the missing nodes
+func (n *node) at(path []string) *node {
+	if len(path) == 0 {
+		return n
+	}
+	if path[0] == "*" {
+		if n.typ == "" {
+			n.typ = "array"
+		}
+		if n.items == nil {
+			n.items = newNode()
+		}
+		return n.items.at(path[1:])
+	}
+	if n.typ == "" {
+		n.typ = "object"
+	}
+	child, ok := n.properties[path[0]]
+	if !ok {
+		child = newNode()
+		n.properties[path[0]] = child
+	}
+	return child.at(path[1:])
+}
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	jsonNumberType = reflect.TypeOf(json.Number(""))
+	bigIntType     = reflect.TypeOf(big.Int{})
+	bigRatType     = reflect.TypeOf(big.Rat{})
+	bigFloatType   = reflect.TypeOf(big.Float{})
+)
+
+// typed sets the type of the node and its children from a Go type
+func (n *node) typed(t reflect.Type, visiting map[reflect.Type]bool) {
+	for t.Kind() == reflect.Ptr {
+		n.nullable = true
+		t = t.Elem()
+	}
+	switch t {
+	case timeType:
+		n.typ = "string"
+		n.format = "date-time"
+		return
+	case jsonNumberType:
+		n.typ = "number"
+		return
+	case bigIntType:
+		n.typ = "integer"
+		return
+	case bigRatType, bigFloatType:
+		// encoded as text, like "1/3"
+		n.typ = "string"
+		return
+	}
+	switch t.Kind() {
+	case reflect.String:
+		n.typ = "string"
+	case reflect.Bool:
+		n.typ = "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n.typ = "integer"
+	case reflect.Float32, reflect.Float64:
+		n.typ = "number"
+	case reflect.Slice, reflect.Array:
+		n.typ = "array"
+		n.items = newNode()
+		n.items.typed(t.Elem(), visiting)
+	case reflect.Map:
+		n.typ = "object"
+	case reflect.Struct:
+		n.typ = "object"
+		if visiting[t] {
+			return
+		}
+		visiting[t] = true
+		defer delete(visiting, t)
+		for i := 0; i < t.NumField(); i++ {
+			name, ok := validation.FieldName(t.Field(i))
+			if !ok {
+				continue
+			}
+			child := newNode()
+			child.typed(t.Field(i).Type, visiting)
+			n.properties[name] = child
+		}
+	}
+}
+
+func (n *node) schema(dialect Dialect, root bool) map[string]interface{} {
+	schema := map[string]interface{}{}
+	if root && dialect == Draft202012 {
+		schema["$schema"] = Draft202012URI
+	}
+	if n.typ != "" {
+		schema["type"] = n.typ
+	}
+	if n.format != "" {
+		schema["format"] = n.format
+	}
+	required := make([]string, 0)
+	keys := make([]string, 0, len(n.properties))
+	for key := range n.properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	if len(keys) > 0 {
+		properties := make(map[string]interface{}, len(keys))
+		for _, key := range keys {
+			child := n.properties[key]
+			properties[key] = child.schema(dialect, false)
+			if child.required() {
+				required = append(required, key)
+			}
+		}
+		schema["properties"] = properties
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	if n.items != nil {
+		schema["items"] = n.items.schema(dialect, false)
+	}
+	for _, rule := range n.rules {
+		if mapper, ok := lookupMapper(rule.Name); ok {
+			mapper(rule, schema)
+		}
+	}
+	if dialect == OpenAPI3 {
+		// OpenAPI 3.0 has the boolean exclusive bounds of JSON Schema draft 4
+		if bound, ok := schema["exclusiveMinimum"]; ok {
+			if _, isBool := bound.(bool); !isBool {
+				schema["minimum"], schema["exclusiveMinimum"] = bound, true
+			}
+		}
+		if bound, ok := schema["exclusiveMaximum"]; ok {
+			if _, isBool := bound.(bool); !isBool {
+				schema["maximum"], schema["exclusiveMaximum"] = bound, true
+			}
+		}
+	}
+	if n.nullable {
+		if dialect == OpenAPI3 {
+			schema["nullable"] = true
+		} else if typ, ok := schema["type"].(string); ok {
+			schema["type"] = []string{typ, "null"}
+		}
+	}
+	return schema
+}
+
+func (n *node) required() bool {
+	for _, rule := range n.rules {
+		if rule.Name == "required" {
+			return true
+		}
+	}
+	return false
+}
+
+// setDefault sets key to value unless it is already set
+func setDefault(schema map[string]interface{}, key string, value interface{}) {
+	if _, ok := schema[key]; !ok {
+		schema[key] = value
+	}
+}
+
+func numberArg(rule validation.Rule, i int) (float64, bool) {
+	if i >= len(rule.Args) {
+		return 0, false
+	}
+	value, err := strconv.ParseFloat(rule.Args[i], 64)
+	return value, err == nil
+}
+
+func intArg(rule validation.Rule, i int) (int, bool) {
+	if i >= len(rule.Args) {
+		return 0, false
+	}
+	value, err := strconv.Atoi(rule.Args[i])
+	return value, err == nil
+}
+
+func keyword(key string, typ string, arg int, parse func(validation.Rule, int) (interface{}, bool)) RuleMapper {
+	return func(rule validation.Rule, schema map[string]interface{}) {
+		if value, ok := parse(rule, arg); ok {
+			setDefault(schema, "type", typ)
+			schema[key] = value
+		}
+	}
+}
+
+func number(rule validation.Rule, i int) (interface{}, bool) {
+	return numberArg(rule, i)
+}
+
+func integer(rule validation.Rule, i int) (interface{}, bool) {
+	return intArg(rule, i)
+}
+
+func format(name string) RuleMapper {
+	return func(rule validation.Rule, schema map[string]interface{}) {
+		setDefault(schema, "type", "string")
+		schema["format"] = name
+	}
+}
+
+func pattern(expr string) RuleMapper {
+	return func(rule validation.Rule, schema map[string]interface{}) {
+		setDefault(schema, "type", "string")
+		schema["pattern"] = expr
+	}
+}
+
+// runeLength applies mapper only to length rules counting runes, e.g. "max_length:10,runes",
+// as minLength and maxLength count code points. n is the number of arguments before the mode
+func runeLength(n int, mapper RuleMapper) RuleMapper {
+	return func(rule validation.Rule, schema map[string]interface{}) {
+		if len(rule.Args) != n+1 {
+			return
+		}
+		if mode, err := validation.ParseLengthMode(rule.Args[n]); err != nil || mode != validation.Runes {
+			return
+		}
+		mapper(rule, schema)
+	}
+}
+
+// enumValue converts an argument of one_of into a value of the JSON type typ
+func enumValue(arg string, typ interface{}) (interface{}, bool) {
+	switch typ {
+	case "integer":
+		value, err := strconv.ParseInt(arg, 10, 64)
+		return value, err == nil
+	case "number":
+		value, err := strconv.ParseFloat(arg, 64)
+		return value, err == nil
+	case "boolean":
+		value, err := strconv.ParseBool(arg)
+		return value, err == nil
+	}
+	return arg, true
+}
+
+func combine(mappers ...RuleMapper) RuleMapper {
+	return func(rule validation.Rule, schema map[string]interface{}) {
+		for _, mapper := range mappers {
+			mapper(rule, schema)
+		}
+	}
+}
+
+// portPattern matches the decimal port numbers from 1 to 65535
+const portPattern = `^([1-9][0-9]{0,3}|[1-5][0-9]{4}|6[0-4][0-9]{3}|65[0-4][0-9]{2}|655[0-2][0-9]|6553[0-5])$`
+
+func init() {
+	RegisterRule("numeric", func(rule validation.Rule, schema map[string]interface{}) {
+		setDefault(schema, "type", "number")
+	})
+	RegisterRule("min", keyword("minimum", "number", 0, number))
+	RegisterRule("max", keyword("maximum", "number", 0, number))
+	RegisterRule("range", combine(keyword("minimum", "number", 0, number), keyword("maximum", "number", 1, number)))
+	RegisterRule("multiple_of", keyword("multipleOf", "number", 0, number))
+	RegisterRule("non_negative", func(rule validation.Rule, schema map[string]interface{}) {
+		setDefault(schema, "type", "number")
+		schema["minimum"] = 0
+	})
+	RegisterRule("positive", func(rule validation.Rule, schema map[string]interface{}) {
+		setDefault(schema, "type", "number")
+		schema["exclusiveMinimum"] = 0
+	})
+	RegisterRule("negative", func(rule validation.Rule, schema map[string]interface{}) {
+		setDefault(schema, "type", "number")
+		schema["exclusiveMaximum"] = 0
+	})
+	RegisterRule("min_length", runeLength(1, keyword("minLength", "string", 0, integer)))
+	RegisterRule("max_length", runeLength(1, keyword("maxLength", "string", 0, integer)))
+	RegisterRule("length", runeLength(2, combine(keyword("minLength", "string", 0, integer), keyword("maxLength", "string", 1, integer))))
+	RegisterRule("min_count", keyword("minItems", "array", 0, integer))
+	RegisterRule("max_count", keyword("maxItems", "array", 0, integer))
+	RegisterRule("one_of", func(rule validation.Rule, schema map[string]interface{}) {
+		setDefault(schema, "type", "string")
+		enum := make([]interface{}, 0, len(rule.Args))
+		for _, arg := range rule.Args {
+			// values that can't be of the type of the node can't match, so they are left out
+			if value, ok := enumValue(arg, schema["type"]); ok {
+				enum = append(enum, value)
+			}
+		}
+		schema["enum"] = enum
+	})
+	RegisterRule("is_email", format("email"))
+	RegisterRule("email", format("email"))
+	RegisterRule("is_uuid", format("uuid"))
+	RegisterRule("is_iso8601", format("date-time"))
+	RegisterRule("is_iso8601_date", format("date"))
+	RegisterRule("is_url", format("uri"))
+	RegisterRule("is_public_url", format("uri"))
+	RegisterRule("is_ipv4", format("ipv4"))
+	RegisterRule("is_ipv6", format("ipv6"))
+	RegisterRule("is_hostname", format("hostname"))
+	RegisterRule("is_fqdn", format("hostname"))
+	RegisterRule("is_port", func(rule validation.Rule, schema map[string]interface{}) {
+		setDefault(schema, "type", "integer")
+		if schema["type"] == "string" {
+			schema["pattern"] = portPattern
+			return
+		}
+		schema["minimum"] = 1
+		schema["maximum"] = 65535
+	})
+	RegisterRule("is_alphanumeric", pattern("^[a-zA-Z0-9]+$"))
+	RegisterRule("is_only_digits", pattern("^[0-9]+$"))
+	RegisterRule("is_phone", pattern(`^(\+|0)[0-9]+$`))
+	RegisterRule("is_nik", pattern("^[0-9]{16}$"))
+	RegisterRule("is_no_kk", pattern("^[0-9]{16}$"))
+	RegisterRule("is_kode_pos", pattern("^[1-9][0-9]{4}$"))
+	RegisterRule("is_bic", pattern("^[A-Z]{6}[A-Z0-9]{2}([A-Z0-9]{3})?$"))
+	RegisterRule("is_issn", pattern("^[0-9]{4}-?[0-9]{3}[0-9Xx]$"))
+	RegisterRule("is_time_of_day", pattern("^([01][0-9]|2[0-3]):[0-5][0-9](:[0-5][0-9])?$"))
+}
@@ -0,0 +1,220 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/mfaizudd/nodebat-go/validation"
+)
+
+type item struct {
+	SKU   string  `json:"sku" validate:"required|is_alphanumeric"`
+	Price float64 `json:"price" validate:"range:0,1000"`
+}
+
+type order struct {
+	ID        string    `json:"id" validate:"required|is_uuid"`
+	Status    string    `json:"status" validate:"one_of:open,closed"`
+	Note      *string   `json:"note" validate:"max_length:10,runes"`
+	Items     []item    `json:"items" validate:"required|min_count:1"`
+	CreatedAt time.Time `json:"created_at"`
+	Quantity  int       `json:"quantity" validate:"min:1"`
+}
+
+func marshal(t *testing.T, v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestFromStruct(t *testing.T) {
+	schema, err := FromStruct(order{}, Draft202012)
+	if err != nil {
+		t.Fatal("Expected error to be nil, got: ", err)
+	}
+	var expected map[string]interface{}
+	_ = json.Unmarshal([]byte(`{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"required": ["id", "items"],
+		"properties": {
+			"id": {"type": "string", "format": "uuid"},
+			"status": {"type": "string", "enum": ["open", "closed"]},
+			"note": {"type": ["string", "null"], "maxLength": 10},
+			"created_at": {"type": "string", "format": "date-time"},
+			"quantity": {"type": "integer", "minimum": 1},
+			"items": {
+				"type": "array",
+				"minItems": 1,
+				"items": {
+					"type": "object",
+					"required": ["sku"],
+					"properties": {
+						"sku": {"type": "string", "pattern": "^[a-zA-Z0-9]+$"},
+						"price": {"type": "number", "minimum": 0, "maximum": 1000}
+					}
+				}
+			}
+		}
+	}`), &expected)
+	var actual map[string]interface{}
+	_ = json.Unmarshal([]byte(marshal(t, schema)), &actual)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %s, got %s", marshal(t, expected), marshal(t, actual))
+	}
+}
+
+func TestFromStructNumbers(t *testing.T) {
+	type ledger struct {
+		Total   json.Number `json:"total" validate:"multiple_of:0.01"`
+		Units   *big.Int    `json:"units" validate:"non_negative"`
+		Balance *big.Rat    `json:"balance"`
+	}
+	schema, err := FromStruct(ledger{}, Draft202012)
+	if err != nil {
+		t.Fatal("Expected error to be nil, got: ", err)
+	}
+	expected := `{"$schema":"https://json-schema.org/draft/2020-12/schema","properties":{"balance":{"type":["string","null"]},"total":{"multipleOf":0.01,"type":"number"},"units":{"minimum":0,"type":["integer","null"]}},"type":"object"}`
+	if actual := marshal(t, schema); actual != expected {
+		t.Fatalf("expected %s, got %s", expected, actual)
+	}
+}
+
+func TestFromStructPort(t *testing.T) {
+	type server struct {
+		Port    int    `json:"port" validate:"is_port"`
+		Address string `json:"address" validate:"is_port"`
+	}
+	schema, err := FromStruct(server{}, Draft202012)
+	if err != nil {
+		t.Fatal("Expected error to be nil, got: ", err)
+	}
+	properties := schema["properties"].(map[string]interface{})
+	port := properties["port"].(map[string]interface{})
+	if port["minimum"] != 1 || port["maximum"] != 65535 {
+		t.Fatalf("expected an integer port range, got %v", port)
+	}
+	address := properties["address"].(map[string]interface{})
+	if address["pattern"] != portPattern || address["minimum"] != nil {
+		t.Fatalf("expected a port pattern, got %v", address)
+	}
+	re := regexp.MustCompile(portPattern)
+	for _, valid := range []string{"1", "80", "8080", "65535"} {
+		if !re.MatchString(valid) {
+			t.Fatalf("port %q should match", valid)
+		}
+	}
+	for _, invalid := range []string{"0", "080", "65536", "99999", "-1"} {
+		if re.MatchString(invalid) {
+			t.Fatalf("port %q should not match", invalid)
+		}
+	}
+}
+
+func TestFromStructOpenAPI(t *testing.T) {
+	schema, err := FromStruct(&order{}, OpenAPI3)
+	if err != nil {
+		t.Fatal("Expected error to be nil, got: ", err)
+	}
+	if _, ok := schema["$schema"]; ok {
+		t.Fatal("OpenAPI schema objects should not have $schema")
+	}
+	note := schema["properties"].(map[string]interface{})["note"].(map[string]interface{})
+	if note["type"] != "string" || note["nullable"] != true {
+		t.Fatalf("expected a nullable string, got %v", note)
+	}
+}
+
+func TestFromRules(t *testing.T) {
+	rules := map[string]string{
+		"email":         "required|is_email",
+		"items":         "min_count:1",
+		"items.*.price": "required|numeric|min:0",
+		"meta.source":   "one_of:web,app",
+	}
+	schema, err := FromRules(rules, Draft202012)
+	if err != nil {
+		t.Fatal("Expected error to be nil, got: ", err)
+	}
+
+	if fromCompiled := FromMapRules(validation.MustCompileMapRules(rules), Draft202012); marshal(t, fromCompiled) != marshal(t, schema) {
+		t.Fatalf("expected %s, got %s", marshal(t, schema), marshal(t, fromCompiled))
+	}
+
+	// the generated schema should accept and reject the same payloads as the rules
+	compiled, err := Compile([]byte(marshal(t, schema)))
+	if err != nil {
+		t.Fatal("Expected error to be nil, got: ", err)
+	}
+	if err := compiled.ValidateJSON([]byte(`{"email": "a@b.c", "items": [{"price": 1}], "meta": {"source": "web"}}`)); err != nil {
+		t.Fatal("Expected error to be nil, got: ", err)
+	}
+	if err := compiled.ValidateJSON([]byte(`{"items": [{"price": -1}], "meta": {"source": "fax"}}`)); err == nil {
+		t.Fatal("Expected error to be not nil")
+	}
+
+	network, err := FromRules(map[string]string{"callback": "is_url:https", "port": "is_port", "host": "is_fqdn"}, Draft202012)
+	if err != nil {
+		t.Fatal("Expected error to be nil, got: ", err)
+	}
+	expected := `{"$schema":"https://json-schema.org/draft/2020-12/schema","properties":{"callback":{"format":"uri","type":"string"},"host":{"format":"hostname","type":"string"},"port":{"maximum":65535,"minimum":1,"type":"integer"}},"type":"object"}`
+	if marshal(t, network) != expected {
+		t.Fatalf("expected %s, got %s", expected, marshal(t, network))
+	}
+
+	if _, err := FromRules(map[string]string{"a": "|:1"}, Draft202012); err == nil {
+		t.Fatal("Expected error to be not nil")
+	}
+}
+
+func TestFromStructRuleTypes(t *testing.T) {
+	type product struct {
+		Size   int     `json:"size" validate:"one_of:1,2,x"`
+		Price  float64 `json:"price" validate:"positive"`
+		Delta  int     `json:"delta" validate:"negative"`
+		Code   string  `json:"code" validate:"length:2,4"`
+		Symbol string  `json:"symbol" validate:"min_length:1,graphemes"`
+	}
+	schema, err := FromStruct(product{}, Draft202012)
+	if err != nil {
+		t.Fatal("Expected error to be nil, got: ", err)
+	}
+	// minLength and maxLength count code points, so lengths in bytes or graphemes are not exported
+	expected := `{"$schema":"https://json-schema.org/draft/2020-12/schema","properties":{"code":{"type":"string"},"delta":{"exclusiveMaximum":0,"type":"integer"},"price":{"exclusiveMinimum":0,"type":"number"},"size":{"enum":[1,2],"type":"integer"},"symbol":{"type":"string"}},"type":"object"}`
+	if actual := marshal(t, schema); actual != expected {
+		t.Fatalf("expected %s, got %s", expected, actual)
+	}
+
+	openapi, err := FromStruct(product{}, OpenAPI3)
+	if err != nil {
+		t.Fatal("Expected error to be nil, got: ", err)
+	}
+	expected = `{"properties":{"code":{"type":"string"},"delta":{"exclusiveMaximum":true,"maximum":0,"type":"integer"},"price":{"exclusiveMinimum":true,"minimum":0,"type":"number"},"size":{"enum":[1,2],"type":"integer"},"symbol":{"type":"string"}},"type":"object"}`
+	if actual := marshal(t, openapi); actual != expected {
+		t.Fatalf("expected %s, got %s", expected, actual)
+	}
+}
+
+func TestFromSchema(t *testing.T) {
+	type user struct {
+		Name  string
+		Email string
+		Age   int
+	}
+	s := validation.NewSchema[user]().
+		Field("name", func(u *user) interface{} { return &u.Name }, "required|max_length:20,runes").
+		Field("email", func(u *user) interface{} { return &u.Email }, "required|is_email").
+		Field("age", func(u *user) interface{} { return &u.Age }, "numeric|min:18").
+		Check("age", func(u *user) validation.Validator { return nil })
+	schema := FromSchema(s, Draft202012)
+	expected := `{"$schema":"https://json-schema.org/draft/2020-12/schema","properties":{"age":{"minimum":18,"type":"number"},"email":{"format":"email","type":"string"},"name":{"maxLength":20,"type":"string"}},"required":["email","name"],"type":"object"}`
+	if actual := marshal(t, schema); actual != expected {
+		t.Fatalf("expected %s, got %s", expected, actual)
+	}
+}
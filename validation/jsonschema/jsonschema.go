@@ -0,0 +1,305 @@
+// Package jsonschema compiles JSON Schema documents into validators built from
+// the rules of the validation package
+//
+// Only a subset of JSON Schema is supported: type, required, properties, items,
+// enum, minLength, maxLength, minimum, maximum, minItems, maxItems and format
+// (email, uuid, date-time, date, uri, ipv4, ipv6 and hostname). Field names of
+// the errors are JSON Pointers, e.g. "/items/0/price", and "" for the root document
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mfaizudd/nodebat-go/validation"
+)
+
+// Schema is a compiled JSON Schema
+type Schema struct {
+	types      []string
+	required   []string
+	properties map[string]*Schema
+	keys       []string
+	items      *Schema
+	enum       []interface{}
+	minLength  *int
+	maxLength  *int
+	minimum    *float64
+	maximum    *float64
+	minItems   *int
+	maxItems   *int
+	format     string
+}
+
+type document struct {
+	Type       json.RawMessage            `json:"type"`
+	Required   []string                   `json:"required"`
+	Properties map[string]json.RawMessage `json:"properties"`
+	Items      json.RawMessage            `json:"items"`
+	Enum       []interface{}              `json:"enum"`
+	MinLength  *int                       `json:"minLength"`
+	MaxLength  *int                       `json:"maxLength"`
+	Minimum    *float64                   `json:"minimum"`
+	Maximum    *float64                   `json:"maximum"`
+	MinItems   *int                       `json:"minItems"`
+	MaxItems   *int                       `json:"maxItems"`
+	Format     string                     `json:"format"`
+}
+
+var types = map[string]bool{
+	"string":  true,
+	"number":  true,
+	"integer": true,
+	"boolean": true,
+	"object":  true,
+	"array":   true,
+	"null":    true,
+}
+
+var formats = map[string]bool{
+	"email":     true,
+	"uuid":      true,
+	"date-time": true,
+	"date":      true,
+	"uri":       true,
+	"ipv4":      true,
+	"ipv6":      true,
+	"hostname":  true,
+}
+
+// Compile compiles a JSON Schema document
+//
+// Unsupported keywords are ignored, but unknown types and formats are reported as an error
+func Compile(data []byte) (*Schema, error) {
+	return compile(data, "")
+}
+
+// MustCompile is like Compile but panics if the schema is invalid
+func MustCompile(data []byte) *Schema {
+	schema, err := Compile(data)
+	if err != nil {
+		panic(err)
+	}
+	return schema
+}
+
+func compile(data []byte, pointer string) (*Schema, error) {
+	var doc document
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("jsonschema: %s: %w", location(pointer), err)
+	}
+	schema := &Schema{
+		required:  doc.Required,
+		enum:      doc.Enum,
+		minLength: doc.MinLength,
+		maxLength: doc.MaxLength,
+		minimum:   doc.Minimum,
+		maximum:   doc.Maximum,
+		minItems:  doc.MinItems,
+		maxItems:  doc.MaxItems,
+		format:    doc.Format,
+	}
+	if len(doc.Type) > 0 {
+		var single string
+		if err := json.Unmarshal(doc.Type, &single); err == nil {
+			schema.types = []string{single}
+		} else if err := json.Unmarshal(doc.Type, &schema.types); err != nil {
+			return nil, fmt.Errorf("jsonschema: %s: invalid type", location(pointer))
+		}
+		for _, typ := range schema.types {
+			if !types[typ] {
+				return nil, fmt.Errorf("jsonschema: %s: unknown type %q", location(pointer), typ)
+			}
+		}
+	}
+	if schema.format != "" && !formats[schema.format] {
+		return nil, fmt.Errorf("jsonschema: %s: unsupported format %q", location(pointer), schema.format)
+	}
+	if len(doc.Properties) > 0 {
+		schema.properties = make(map[string]*Schema, len(doc.Properties))
+		for key, raw := range doc.Properties {
+			property, err := compile(raw, pointer+"/properties/"+escape(key))
+			if err != nil {
+				return nil, err
+			}
+			schema.properties[key] = property
+			schema.keys = append(schema.keys, key)
+		}
+		sort.Strings(schema.keys)
+	}
+	if len(doc.Items) > 0 {
+		items, err := compile(doc.Items, pointer+"/items")
+		if err != nil {
+			return nil, err
+		}
+		schema.items = items
+	}
+	return schema, nil
+}
+
+// Validate validates a decoded JSON document, such as the result of
+// json.Unmarshal into an interface{}, and returns a validation.Error, if any
+func (s *Schema) Validate(doc interface{}) error {
+	v := validation.New()
+	s.Apply(v, doc)
+	return v.Error()
+}
+
+// ValidateJSON decodes data and validates it
+func (s *Schema) ValidateJSON(data []byte) error {
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	return s.Validate(doc)
+}
+
+// Apply validates a decoded JSON document and adds the errors to v
+func (s *Schema) Apply(v *validation.Validation, doc interface{}) {
+	s.apply(v, "", doc)
+}
+
+func (s *Schema) apply(v *validation.Validation, pointer string, value interface{}) {
+	if number, ok := value.(json.Number); ok {
+		if f, err := number.Float64(); err == nil {
+			value = f
+		}
+	}
+	// the field is the JSON Pointer of the value, so the root document is ""
+	field := pointer
+	v.Add(field, s.checkType(value))
+	if len(s.enum) > 0 {
+		v.Add(field, enum(value, s.enum))
+	}
+	switch value := value.(type) {
+	case string:
+		// JSON Schema counts string length in code points
+		b := v.Builder(field, value).LengthMode(validation.Runes)
+		if s.minLength != nil {
+			b.MinLength(*s.minLength)
+		}
+		if s.maxLength != nil {
+			b.MaxLength(*s.maxLength)
+		}
+		switch s.format {
+		case "email":
+			b.Email(validation.EmailConfig{})
+		case "uuid":
+			b.IsUUID()
+		case "date-time":
+			b.IsISO8601()
+		case "date":
+			b.IsISO8601Date()
+		case "uri":
+			b.IsURL()
+		case "ipv4":
+			b.IsIPv4()
+		case "ipv6":
+			b.IsIPv6()
+		case "hostname":
+			b.IsHostname()
+		}
+	case float64:
+		if s.minimum != nil {
+			v.Add(field, validation.Min(value, *s.minimum))
+		}
+		if s.maximum != nil {
+			v.Add(field, validation.Max(value, *s.maximum))
+		}
+	case []interface{}:
+		b := v.Builder(field, value)
+		if s.minItems != nil {
+			b.MinCount(*s.minItems)
+		}
+		if s.maxItems != nil {
+			b.MaxCount(*s.maxItems)
+		}
+		if s.items != nil {
+			for i, item := range value {
+				s.items.apply(v, pointer+"/"+strconv.Itoa(i), item)
+			}
+		}
+	case map[string]interface{}:
+		for _, key := range s.required {
+			if _, ok := value[key]; !ok {
+				v.Add(pointer+"/"+escape(key), validation.Required(nil))
+			}
+		}
+		for _, key := range s.keys {
+			if property, ok := value[key]; ok {
+				s.properties[key].apply(v, pointer+"/"+escape(key), property)
+			}
+		}
+	}
+}
+
+func (s *Schema) checkType(value interface{}) validation.Validator {
+	return func(field string) *validation.FieldError {
+		if len(s.types) == 0 {
+			return nil
+		}
+		actual := typeOf(value)
+		for _, typ := range s.types {
+			if typ == actual || (typ == "number" && actual == "integer") {
+				return nil
+			}
+		}
+		msg := fmt.Sprintf("%s must be of type %s", field, strings.Join(s.types, " or "))
+		err := validation.NewFieldError(field, msg, "type", value)
+		err.SetParam("type", s.types)
+		return err
+	}
+}
+
+func enum(value interface{}, collection []interface{}) validation.Validator {
+	return func(field string) *validation.FieldError {
+		for _, item := range collection {
+			if reflect.DeepEqual(value, item) {
+				return nil
+			}
+		}
+		msg := fmt.Sprintf("%s is not in the collection", field)
+		err := validation.NewFieldError(field, msg, "one_of", value)
+		err.SetParam("collection", collection)
+		return err
+	}
+}
+
+func typeOf(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case float64:
+		if value == math.Trunc(value) {
+			return "integer"
+		}
+		return "number"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return ""
+}
+
+// location describes pointer in compile errors, the root document being an empty pointer
+func location(pointer string) string {
+	if pointer == "" {
+		return "root"
+	}
+	return pointer
+}
+
+// escape escapes a key for use in a JSON Pointer (RFC 6901)
+func escape(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
@@ -0,0 +1,166 @@
+package jsonschema
+
+import (
+	"testing"
+
+	"github.com/mfaizudd/nodebat-go/validation"
+)
+
+var orderSchema = []byte(`{
+	"type": "object",
+	"required": ["id", "email", "items"],
+	"properties": {
+		"id": {"type": "string", "format": "uuid"},
+		"email": {"type": "string", "format": "email"},
+		"status": {"enum": ["open", "closed"]},
+		"created_at": {"type": "string", "format": "date-time"},
+		"note": {"type": ["string", "null"], "maxLength": 10},
+		"items": {
+			"type": "array",
+			"minItems": 1,
+			"items": {
+				"type": "object",
+				"required": ["price"],
+				"properties": {
+					"sku": {"type": "string", "minLength": 2},
+					"price": {"type": "number", "minimum": 0, "maximum": 1000},
+					"quantity": {"type": "integer"}
+				}
+			}
+		}
+	}
+}`)
+
+func TestValidate(t *testing.T) {
+	schema := MustCompile(orderSchema)
+	err := schema.ValidateJSON([]byte(`{
+		"id": "6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		"email": "test@email.com",
+		"status": "open",
+		"created_at": "2018-01-01T00:00:00Z",
+		"note": null,
+		"items": [{"sku": "a1", "price": 10.5, "quantity": 2}]
+	}`))
+	if err != nil {
+		t.Fatal("Expected error to be nil, got: ", err)
+	}
+}
+
+func TestValidateErrors(t *testing.T) {
+	schema := MustCompile(orderSchema)
+	err := schema.ValidateJSON([]byte(`{
+		"id": "not a uuid",
+		"status": "pending",
+		"note": "a very long note",
+		"items": [{"sku": "a", "price": -1, "quantity": 1.5}, {"sku": 1}, "item"]
+	}`))
+	if err == nil {
+		t.Fatal("Expected error to be not nil")
+	}
+	expected := map[string]string{
+		"/id":               "is_uuid",
+		"/email":            "required",
+		"/status":           "one_of",
+		"/note":             "max_length",
+		"/items/0/sku":      "min_length",
+		"/items/0/price":    "min",
+		"/items/0/quantity": "type",
+		"/items/1/price":    "required",
+		"/items/1/sku":      "type",
+		"/items/2":          "type",
+	}
+	errors := err.(validation.Error).Errors()
+	if len(errors) != len(expected) {
+		t.Fatalf("expected %d errors, got %v", len(expected), err)
+	}
+	for field, tag := range expected {
+		if errors[field] == nil || errors[field].Tag() != tag {
+			t.Fatalf("expected %s to fail with %s, got %v", field, tag, errors[field])
+		}
+	}
+}
+
+func TestFormats(t *testing.T) {
+	schema := MustCompile([]byte(`{
+		"type": "object",
+		"properties": {
+			"callback": {"type": "string", "format": "uri"},
+			"ipv4": {"type": "string", "format": "ipv4"},
+			"ipv6": {"type": "string", "format": "ipv6"},
+			"host": {"type": "string", "format": "hostname"}
+		}
+	}`))
+	err := schema.ValidateJSON([]byte(`{"callback": "https://example.com", "ipv4": "10.0.0.1", "ipv6": "::1", "host": "db-1"}`))
+	if err != nil {
+		t.Fatal("Expected error to be nil, got: ", err)
+	}
+	err = schema.ValidateJSON([]byte(`{"callback": "/hook", "ipv4": "::1", "ipv6": "10.0.0.1", "host": "db_1"}`))
+	if err == nil || len(err.(validation.Error).Errors()) != 4 {
+		t.Fatalf("expected 4 errors, got %v", err)
+	}
+}
+
+func TestCodePointsAndLargeIntegers(t *testing.T) {
+	schema := MustCompile([]byte(`{
+		"type": "object",
+		"properties": {
+			"name": {"type": "string", "minLength": 3, "maxLength": 3},
+			"count": {"type": "integer"}
+		}
+	}`))
+	err := schema.ValidateJSON([]byte(`{"name": "Zoë", "count": 1e20}`))
+	if err != nil {
+		t.Fatal("Expected error to be nil, got: ", err)
+	}
+	err = schema.ValidateJSON([]byte(`{"name": "Zoëy", "count": 1e20}`))
+	if err == nil || len(err.(validation.Error).Errors()) != 1 {
+		t.Fatalf("expected 1 error, got %v", err)
+	}
+}
+
+func TestRootPointer(t *testing.T) {
+	schema := MustCompile([]byte(`{"type": "object", "properties": {"": {"type": "string"}}}`))
+	err := schema.ValidateJSON([]byte(`{"": 1}`))
+	if err == nil || err.(validation.Error).Errors()["/"] == nil {
+		t.Fatalf(`expected an error on the property named "", got %v`, err)
+	}
+	err = schema.ValidateJSON([]byte(`[]`))
+	if err == nil || err.(validation.Error).Errors()[""] == nil {
+		t.Fatalf("expected an error on the root document, got %v", err)
+	}
+}
+
+func TestEmailFormat(t *testing.T) {
+	schema := MustCompile([]byte(`{"type": "string", "format": "email"}`))
+	if err := schema.ValidateJSON([]byte(`"bob@example.com"`)); err != nil {
+		t.Fatal("Expected error to be nil, got: ", err)
+	}
+	if err := schema.ValidateJSON([]byte(`"Bob <bob@example.com>"`)); err == nil {
+		t.Fatal("an address with a display name should not be an email")
+	}
+}
+
+func TestCompileInvalid(t *testing.T) {
+	invalidSchemas := []string{
+		`{"type": "strin"}`,
+		`{"type": 1}`,
+		`{"format": "ipv9"}`,
+		`{"properties": {"a": {"type": "nope"}}}`,
+		`{"items": []}`,
+		`not json`,
+	}
+	for _, schema := range invalidSchemas {
+		if _, err := Compile([]byte(schema)); err == nil {
+			t.Fatalf(`schema %s is valid, it should be invalid`, schema)
+		}
+	}
+}
+
+func TestEscape(t *testing.T) {
+	schema := MustCompile([]byte(`{"required": ["a/b", "c~d"]}`))
+	err := schema.Validate(map[string]interface{}{})
+	errors := err.(validation.Error).Errors()
+	if errors["/a~1b"] == nil || errors["/c~0d"] == nil {
+		t.Fatalf("expected escaped pointers, got %v", err)
+	}
+}
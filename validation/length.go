@@ -0,0 +1,110 @@
+package validation
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/rivo/uniseg"
+)
+
+// LengthMode is how the length of a string is counted by the length validators
+//
+// The zero value counts bytes, like Bytes
+type LengthMode int
+
+const (
+	// Bytes counts the bytes of the string, like len
+	Bytes LengthMode = iota + 1
+	// Runes counts the unicode code points of the string, so "Zoë" is 3 characters long
+	Runes
+	// Graphemes counts the user perceived characters of the string, the extended grapheme
+	// clusters of Unicode (UAX #29), so an emoji made of several code points, or a letter
+	// followed by combining marks, counts as one
+	Graphemes
+)
+
+// String returns the name of the mode, which is used as the mode param of the errors
+func (m LengthMode) String() string {
+	switch m {
+	case Runes:
+		return "runes"
+	case Graphemes:
+		return "graphemes"
+	default:
+		return "bytes"
+	}
+}
+
+// Count returns the length of s counted using the mode
+func (m LengthMode) Count(s string) int {
+	switch m {
+	case Runes:
+		return utf8.RuneCountInString(s)
+	case Graphemes:
+		return uniseg.GraphemeClusterCount(s)
+	default:
+		return len(s)
+	}
+}
+
+// ParseLengthMode returns the mode named name, see LengthMode.String
+func ParseLengthMode(name string) (LengthMode, error) {
+	switch name {
+	case "bytes":
+		return Bytes, nil
+	case "runes":
+		return Runes, nil
+	case "graphemes":
+		return Graphemes, nil
+	}
+	return 0, fmt.Errorf("validation: unknown length mode %q", name)
+}
+
+// MinLengthIn checks if the data is at least min characters long, counted using mode
+//
+// Has two parameters: min (int), mode (string)
+func MinLengthIn(data string, min int, mode LengthMode) Validator {
+	return func(field string) *FieldError {
+		if mode.Count(data) < min {
+			msg := fmt.Sprintf("%s must be at least %d characters long", field, min)
+			err := NewFieldError(field, msg, "min_length", data)
+			err.SetParam("min", min)
+			err.SetParam("mode", mode.String())
+			return err
+		}
+		return nil
+	}
+}
+
+// MaxLengthIn checks if the data is at most max characters long, counted using mode
+//
+// Has two parameters: max (int), mode (string)
+func MaxLengthIn(data string, max int, mode LengthMode) Validator {
+	return func(field string) *FieldError {
+		if mode.Count(data) > max {
+			msg := fmt.Sprintf("%s must be at most %d characters long", field, max)
+			err := NewFieldError(field, msg, "max_length", data)
+			err.SetParam("max", max)
+			err.SetParam("mode", mode.String())
+			return err
+		}
+		return nil
+	}
+}
+
+// LengthIn checks if the data is between min and max characters long, counted using mode
+//
+// Has three parameters: min (int), max (int), mode (string)
+func LengthIn(data string, min int, max int, mode LengthMode) Validator {
+	return func(field string) *FieldError {
+		if length := mode.Count(data); length < min || length > max {
+			msg := fmt.Sprintf("%s must be between %d and %d characters long", field, min, max)
+			err := NewFieldError(field, msg, "length", data)
+			err.SetParam("min", min)
+			err.SetParam("max", max)
+			err.SetParam("mode", mode.String())
+			return err
+		}
+		return nil
+	}
+}
@@ -0,0 +1,98 @@
+package validation
+
+import "testing"
+
+func TestLengthModeCount(t *testing.T) {
+	testCases := []struct {
+		value     string
+		bytes     int
+		runes     int
+		graphemes int
+	}{
+		{"Zoë", 4, 3, 3},
+		{"Zoe\u0308", 5, 4, 3},
+		{"Dwi Ayu Lestari", 15, 15, 15},
+		{"Ngurah Rai 👋🏽", 19, 13, 12},
+		{"\U0001F468\u200d\U0001F469\u200d\U0001F467", 18, 5, 1},
+		{"🇮🇩🇲🇾", 16, 4, 2},
+		{"🇮🇩🇲", 12, 3, 2},
+		{"a\r\nb", 4, 4, 3},
+		// conjoining jamo make a single Hangul syllable
+		{"\u1100\u1161\u11a8", 9, 3, 1},
+		{"", 0, 0, 0},
+	}
+	for _, testCase := range testCases {
+		if count := Bytes.Count(testCase.value); count != testCase.bytes {
+			t.Fatalf(`value %q should be %d bytes long, got %d`, testCase.value, testCase.bytes, count)
+		}
+		if count := Runes.Count(testCase.value); count != testCase.runes {
+			t.Fatalf(`value %q should be %d runes long, got %d`, testCase.value, testCase.runes, count)
+		}
+		if count := Graphemes.Count(testCase.value); count != testCase.graphemes {
+			t.Fatalf(`value %q should be %d graphemes long, got %d`, testCase.value, testCase.graphemes, count)
+		}
+	}
+}
+
+func TestLengthIn(t *testing.T) {
+	testCases := []struct {
+		validator Validator
+		expected  bool
+	}{
+		{MaxLengthIn("Zoë", 3, Runes), true},
+		{MaxLengthIn("Zoë", 3, Bytes), false},
+		{MinLengthIn("\U0001F468\u200d\U0001F469\u200d\U0001F467", 2, Graphemes), false},
+		{MinLengthIn("\U0001F468\u200d\U0001F469\u200d\U0001F467", 2, Runes), true},
+		{LengthIn("Zoë", 3, 3, Graphemes), true},
+		{LengthIn("Zoë", 3, 3, 0), false},
+	}
+	for i, testCase := range testCases {
+		v := New()
+		v.Add("test", testCase.validator)
+		if testCase.expected && v.Error() != nil {
+			t.Fatalf(`case %d is invalid, it should be valid`, i)
+		}
+		if !testCase.expected && v.Error() == nil {
+			t.Fatalf(`case %d is valid, it should be invalid`, i)
+		}
+	}
+}
+
+func TestLengthModeParam(t *testing.T) {
+	err := MaxLengthIn("Zoë", 2, Runes)("name")
+	if err == nil || err.Param("mode") != "runes" {
+		t.Fatalf("expected the mode param to be runes, got %v", err)
+	}
+	err = MinLength("Zoë", 5)("name")
+	if err == nil || err.Param("mode") != "bytes" {
+		t.Fatalf("expected the mode param to be bytes, got %v", err)
+	}
+}
+
+func TestLengthModeBuilder(t *testing.T) {
+	v := New()
+	v.Builder("per_rule", "Zoë").LengthMode(Runes).Length(3, 3)
+	v.Builder("bytes", "Zoë").MaxLength(3)
+	if err := v.Error(); err == nil || len(err.(Error).Errors()) != 1 || err.(Error).Errors()["bytes"] == nil {
+		t.Fatalf("expected only bytes to fail, got %v", err)
+	}
+
+	v = New()
+	v.SetLengthMode(Graphemes)
+	v.Builder("validation", "Zoë").MaxLength(3)
+	v.Builder("rules", "Zoë").Rules("max_length:3,runes|min_length:4,bytes")
+	if err := v.Error(); err != nil {
+		t.Fatal("Expected error to be nil, got: ", err)
+	}
+}
+
+func TestParseLengthMode(t *testing.T) {
+	for _, mode := range []LengthMode{Bytes, Runes, Graphemes} {
+		if parsed, err := ParseLengthMode(mode.String()); err != nil || parsed != mode {
+			t.Fatalf("expected %v, got %v (%v)", mode, parsed, err)
+		}
+	}
+	if _, err := CompileRuleSet("min_length:3,letters"); err == nil {
+		t.Fatal("Expected error to be not nil")
+	}
+}
@@ -0,0 +1,149 @@
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MapRules is a compiled set of rule strings keyed by path,
+// used to validate dynamic payloads such as JSON decoded into a map[string]interface{}
+//
+// Paths use dot notation, and "*" matches every element of a slice or every key of a map,
+// e.g. "items.*.price". Wildcards are expanded into concrete field names such as "items.0.price"
+type MapRules struct {
+	paths []mapRule
+}
+
+type mapRule struct {
+	path     string
+	segments []string
+	rules    *RuleSet
+}
+
+// CompileMapRules compiles rules, a map of path to rule string
+func CompileMapRules(rules map[string]string) (*MapRules, error) {
+	compiled := &MapRules{paths: make([]mapRule, 0, len(rules))}
+	for path, rule := range rules {
+		set, err := CompileRuleSet(rule)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		compiled.paths = append(compiled.paths, mapRule{path, strings.Split(path, "."), set})
+	}
+	sort.Slice(compiled.paths, func(i, j int) bool {
+		return compiled.paths[i].path < compiled.paths[j].path
+	})
+	return compiled, nil
+}
+
+// MustCompileMapRules is like CompileMapRules but panics if a rule string is invalid
+func MustCompileMapRules(rules map[string]string) *MapRules {
+	compiled, err := CompileMapRules(rules)
+	if err != nil {
+		panic(err)
+	}
+	return compiled
+}
+
+// Rules returns the compiled rule set of every path
+func (r *MapRules) Rules() map[string]*RuleSet {
+	rules := make(map[string]*RuleSet, len(r.paths))
+	for _, path := range r.paths {
+		rules[path.path] = path.rules
+	}
+	return rules
+}
+
+// Validate validates data and returns the validation error, if any
+func (r *MapRules) Validate(data map[string]interface{}) error {
+	v := New()
+	r.Apply(v, data)
+	return v.Error()
+}
+
+// Apply validates data and adds the errors to v
+//
+// Missing values and nulls are treated like a nil pointer:
+// required fails and the other rules are skipped
+func (r *MapRules) Apply(v *Validation, data map[string]interface{}) {
+	r.applyValue(v, reflect.ValueOf(data))
+}
+
+func (r *MapRules) applyValue(v *Validation, data reflect.Value) {
+	for _, path := range r.paths {
+		expandPath(data, path.segments, "", func(field string, value interface{}) {
+			path.rules.Apply(v.Builder(field, value))
+		})
+	}
+}
+
+// expandPath walks value following segments and calls fn for every value found.
+// Wildcards are expanded into every element, and missing values are reported as a nil *string
+func expandPath(value reflect.Value, segments []string, prefix string, fn func(field string, value interface{})) {
+	for value.IsValid() && value.Kind() == reflect.Interface {
+		value = value.Elem()
+	}
+	for len(segments) > 0 && value.IsValid() && value.Kind() == reflect.Ptr {
+		value = value.Elem()
+	}
+	if len(segments) == 0 {
+		if !value.IsValid() || (value.Kind() == reflect.Ptr && value.IsNil()) {
+			fn(prefix, (*string)(nil))
+			return
+		}
+		fn(prefix, value.Interface())
+		return
+	}
+	segment := segments[0]
+	join := func(key string) string {
+		if prefix == "" {
+			return key
+		}
+		return prefix + "." + key
+	}
+	if segment == "*" {
+		switch value.Kind() {
+		case reflect.Slice, reflect.Array:
+			for i := 0; i < value.Len(); i++ {
+				expandPath(value.Index(i), segments[1:], join(strconv.Itoa(i)), fn)
+			}
+		case reflect.Map:
+			keys := value.MapKeys()
+			sort.Slice(keys, func(i, j int) bool {
+				return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+			})
+			for _, key := range keys {
+				expandPath(value.MapIndex(key), segments[1:], join(fmt.Sprint(key.Interface())), fn)
+			}
+		}
+		return
+	}
+	var next reflect.Value
+	switch value.Kind() {
+	case reflect.Map:
+		if value.Type().Key().Kind() == reflect.String {
+			next = value.MapIndex(reflect.ValueOf(segment).Convert(value.Type().Key()))
+		}
+	case reflect.Slice, reflect.Array:
+		if i, err := strconv.Atoi(segment); err == nil && i >= 0 && i < value.Len() {
+			next = value.Index(i)
+		}
+	case reflect.Struct:
+		next = structField(value, segment)
+	}
+	expandPath(next, segments[1:], join(segment), fn)
+}
+
+// structField returns the field of a struct named name, see FieldName
+func structField(value reflect.Value, name string) reflect.Value {
+	t := value.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if fieldName, ok := FieldName(t.Field(i)); ok && fieldName == name {
+			return value.Field(i)
+		}
+	}
+	return reflect.Value{}
+}
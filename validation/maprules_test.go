@@ -0,0 +1,98 @@
+package validation
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMapRules(t *testing.T) {
+	rules := MustCompileMapRules(map[string]string{
+		"name":          "required|min_length:3",
+		"email":         "trim|is_email",
+		"items":         "required|min_count:1",
+		"items.*.price": "required|numeric|min:0",
+		"items.*.sku":   "required|is_alphanumeric",
+		"meta.source":   "one_of:web,app",
+		"tags.*":        "max_length:5",
+	})
+
+	var valid map[string]interface{}
+	_ = json.Unmarshal([]byte(`{
+		"name": "order",
+		"items": [{"price": 10, "sku": "a1"}, {"price": "2.5", "sku": "b2"}],
+		"meta": {"source": "web"},
+		"tags": ["new"]
+	}`), &valid)
+	if err := rules.Validate(valid); err != nil {
+		t.Fatal("Expected error to be nil, got: ", err)
+	}
+
+	var invalid map[string]interface{}
+	_ = json.Unmarshal([]byte(`{
+		"name": "or",
+		"email": "not an email",
+		"items": [{"price": -1, "sku": "a1"}, {"sku": null}],
+		"meta": {"source": "fax"},
+		"tags": ["new", "very long"]
+	}`), &invalid)
+	err := rules.Validate(invalid)
+	if err == nil {
+		t.Fatal("Expected error to be not nil")
+	}
+	expected := map[string]string{
+		"name":          "min_length",
+		"email":         "is_email",
+		"items.0.price": "min",
+		"items.1.price": "required",
+		"items.1.sku":   "required",
+		"meta.source":   "one_of",
+		"tags.1":        "max_length",
+	}
+	errors := err.(Error).Errors()
+	if len(errors) != len(expected) {
+		t.Fatalf("expected %d errors, got %v", len(expected), err)
+	}
+	for field, tag := range expected {
+		if errors[field] == nil || errors[field].Tag() != tag {
+			t.Fatalf("expected %s to fail with %s, got %v", field, tag, errors[field])
+		}
+	}
+}
+
+func TestMapRulesMissing(t *testing.T) {
+	rules := MustCompileMapRules(map[string]string{
+		"items":         "min_count:1",
+		"items.*.price": "required",
+		"meta.source":   "required",
+	})
+	err := rules.Validate(map[string]interface{}{})
+	if err == nil {
+		t.Fatal("Expected error to be not nil")
+	}
+	errors := err.(Error).Errors()
+	if len(errors) != 1 || errors["meta.source"] == nil {
+		t.Fatalf("expected only meta.source to fail, got %v", err)
+	}
+}
+
+func TestMapRulesMissingOptional(t *testing.T) {
+	rules := MustCompileMapRules(map[string]string{
+		"note":   "min:1",
+		"amount": "numeric|range:1,10|multiple_of:0.5",
+		"code":   "trim|min_length:3|required",
+	})
+	err := rules.Validate(map[string]interface{}{"amount": nil})
+	if err == nil {
+		t.Fatal("Expected error to be not nil")
+	}
+	errors := err.(Error).Errors()
+	if len(errors) != 1 || errors["code"] == nil || errors["code"].Tag() != "required" {
+		t.Fatalf("expected only code to fail with required, got %v", err)
+	}
+}
+
+func TestCompileMapRulesInvalid(t *testing.T) {
+	if _, err := CompileMapRules(map[string]string{"name": "unknown"}); err == nil {
+		t.Fatal("Expected error to be not nil")
+	}
+}
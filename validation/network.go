@@ -0,0 +1,382 @@
+package validation
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"strings"
+)
+
+// URLOption configures IsURL
+type URLOption func(*urlOptions)
+
+type urlOptions struct {
+	schemes     []string
+	requireHost bool
+}
+
+// URLSchemes only allows URLs with one of the given schemes, compared case insensitively
+func URLSchemes(schemes ...string) URLOption {
+	return func(o *urlOptions) {
+		o.schemes = schemes
+	}
+}
+
+// URLRequireHost rejects URLs without a host, e.g. "mailto:foo@example.com"
+func URLRequireHost() URLOption {
+	return func(o *urlOptions) {
+		o.requireHost = true
+	}
+}
+
+// IsURL checks if the data is an absolute URL
+//
+// Has one parameter when URLSchemes is used: schemes ([]string)
+func IsURL(value string, options ...URLOption) Validator {
+	var opts urlOptions
+	for _, option := range options {
+		option(&opts)
+	}
+	return func(field string) *FieldError {
+		if !isURL(value, opts) {
+			msg := fmt.Sprintf("%s is not a valid URL", field)
+			err := NewFieldError(field, msg, "is_url", value)
+			if len(opts.schemes) > 0 {
+				err.SetParam("schemes", opts.schemes)
+			}
+			return err
+		}
+		return nil
+	}
+}
+
+func isURL(value string, opts urlOptions) bool {
+	if value == "" || strings.ContainsAny(value, " \t\r\n") {
+		return false
+	}
+	u, err := url.Parse(value)
+	if err != nil || u.Scheme == "" {
+		return false
+	}
+	if opts.requireHost && u.Hostname() == "" {
+		return false
+	}
+	if len(opts.schemes) == 0 {
+		return true
+	}
+	for _, scheme := range opts.schemes {
+		if strings.EqualFold(u.Scheme, scheme) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsIP checks if the data is an IPv4 or IPv6 address
+func IsIP(value string) Validator {
+	return func(field string) *FieldError {
+		if _, ok := parseIP(value); !ok {
+			msg := fmt.Sprintf("%s is not a valid IP address", field)
+			return NewFieldError(field, msg, "is_ip", value)
+		}
+		return nil
+	}
+}
+
+// IsIPv4 checks if the data is an IPv4 address in dotted decimal notation
+func IsIPv4(value string) Validator {
+	return func(field string) *FieldError {
+		if addr, ok := parseIP(value); !ok || !addr.Is4() {
+			msg := fmt.Sprintf("%s is not a valid IPv4 address", field)
+			return NewFieldError(field, msg, "is_ipv4", value)
+		}
+		return nil
+	}
+}
+
+// IsIPv6 checks if the data is an IPv6 address
+func IsIPv6(value string) Validator {
+	return func(field string) *FieldError {
+		if addr, ok := parseIP(value); !ok || !addr.Is6() {
+			msg := fmt.Sprintf("%s is not a valid IPv6 address", field)
+			return NewFieldError(field, msg, "is_ipv6", value)
+		}
+		return nil
+	}
+}
+
+// parseIP parses an IP address without a zone, e.g. "fe80::1%eth0" is rejected
+func parseIP(value string) (netip.Addr, bool) {
+	addr, err := netip.ParseAddr(value)
+	if err != nil || addr.Zone() != "" {
+		return netip.Addr{}, false
+	}
+	return addr, true
+}
+
+// IsCIDR checks if the data is an IP address with a prefix length, e.g. "10.0.0.0/8"
+func IsCIDR(value string) Validator {
+	return func(field string) *FieldError {
+		if _, err := netip.ParsePrefix(value); err != nil {
+			msg := fmt.Sprintf("%s is not a valid CIDR", field)
+			return NewFieldError(field, msg, "is_cidr", value)
+		}
+		return nil
+	}
+}
+
+// IsHostname checks if the data is a valid host name as defined by RFC 1123
+//
+// Labels contain letters, digits and hyphens, are at most 63 characters long
+// and don't start or end with a hyphen. The host name is at most 253 characters long
+func IsHostname(value string) Validator {
+	return func(field string) *FieldError {
+		if !isHostname(value) {
+			msg := fmt.Sprintf("%s is not a valid hostname", field)
+			return NewFieldError(field, msg, "is_hostname", value)
+		}
+		return nil
+	}
+}
+
+// IsFQDN checks if the data is a fully qualified domain name, e.g. "api.example.com"
+//
+// It is a host name with at least two labels and a top level domain that is not
+// only digits. A trailing dot is allowed
+func IsFQDN(value string) Validator {
+	return func(field string) *FieldError {
+		if !isFQDN(value) {
+			msg := fmt.Sprintf("%s is not a valid fully qualified domain name", field)
+			return NewFieldError(field, msg, "is_fqdn", value)
+		}
+		return nil
+	}
+}
+
+func isHostname(value string) bool {
+	if value == "" || len(value) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(value, ".") {
+		if !isHostnameLabel(label) {
+			return false
+		}
+	}
+	return true
+}
+
+func isHostnameLabel(label string) bool {
+	if label == "" || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+		return false
+	}
+	for i := 0; i < len(label); i++ {
+		c := label[i]
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-') {
+			return false
+		}
+	}
+	return true
+}
+
+func isFQDN(value string) bool {
+	value = strings.TrimSuffix(value, ".")
+	if !isHostname(value) {
+		return false
+	}
+	dot := strings.LastIndexByte(value, '.')
+	return dot > 0 && !onlyDigitsRegex.MatchString(value[dot+1:])
+}
+
+// IsMAC checks if the data is a MAC address, e.g. "00:00:5e:00:53:01",
+// "00-00-5e-00-53-01" or "0000.5e00.5301"
+func IsMAC(value string) Validator {
+	return func(field string) *FieldError {
+		if _, err := net.ParseMAC(value); err != nil {
+			msg := fmt.Sprintf("%s is not a valid MAC address", field)
+			return NewFieldError(field, msg, "is_mac", value)
+		}
+		return nil
+	}
+}
+
+// IsPort checks if the data is a port number between 1 and 65535
+func IsPort[T Integer](port T) Validator {
+	return func(field string) *FieldError {
+		if port < 1 || uint64(port) > 65535 {
+			msg := fmt.Sprintf("%s is not a valid port", field)
+			return NewFieldError(field, msg, "is_port", port)
+		}
+		return nil
+	}
+}
+
+// IPInRange checks if the data is an IP address in one of the given CIDRs, e.g. "10.0.0.0/8"
+//
+// It panics if a CIDR is invalid. Has one parameter: ranges ([]string)
+func IPInRange(value string, cidrs ...string) Validator {
+	prefixes, err := parsePrefixes(cidrs)
+	if err != nil {
+		panic(err)
+	}
+	return ipInRange(value, cidrs, prefixes)
+}
+
+func ipInRange(value string, cidrs []string, prefixes []netip.Prefix) Validator {
+	return func(field string) *FieldError {
+		if addr, ok := parseIP(value); ok {
+			addr = addr.Unmap()
+			for _, prefix := range prefixes {
+				if prefix.Contains(addr) {
+					return nil
+				}
+			}
+		}
+		msg := fmt.Sprintf("%s is not in an allowed IP range", field)
+		err := NewFieldError(field, msg, "ip_in_range", value)
+		err.SetParam("ranges", cidrs)
+		return err
+	}
+}
+
+func parsePrefixes(cidrs []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, len(cidrs))
+	for i, cidr := range cidrs {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			return nil, err
+		}
+		prefixes[i] = prefix
+	}
+	return prefixes, nil
+}
+
+// IsURL checks if the data is an absolute URL
+func (v *Builder) IsURL(options ...URLOption) *Builder {
+	if v.hasError() {
+		return v
+	}
+	value, ok := v.getString()
+	if ok {
+		v.validation.Add(v.field, IsURL(value, options...))
+	}
+	return v
+}
+
+// IsIP checks if the data is an IPv4 or IPv6 address
+func (v *Builder) IsIP() *Builder {
+	if v.hasError() {
+		return v
+	}
+	value, ok := v.getString()
+	if ok {
+		v.validation.Add(v.field, IsIP(value))
+	}
+	return v
+}
+
+// IsIPv4 checks if the data is an IPv4 address in dotted decimal notation
+func (v *Builder) IsIPv4() *Builder {
+	if v.hasError() {
+		return v
+	}
+	value, ok := v.getString()
+	if ok {
+		v.validation.Add(v.field, IsIPv4(value))
+	}
+	return v
+}
+
+// IsIPv6 checks if the data is an IPv6 address
+func (v *Builder) IsIPv6() *Builder {
+	if v.hasError() {
+		return v
+	}
+	value, ok := v.getString()
+	if ok {
+		v.validation.Add(v.field, IsIPv6(value))
+	}
+	return v
+}
+
+// IsCIDR checks if the data is an IP address with a prefix length, e.g. "10.0.0.0/8"
+func (v *Builder) IsCIDR() *Builder {
+	if v.hasError() {
+		return v
+	}
+	value, ok := v.getString()
+	if ok {
+		v.validation.Add(v.field, IsCIDR(value))
+	}
+	return v
+}
+
+// IsHostname checks if the data is a valid host name as defined by RFC 1123
+func (v *Builder) IsHostname() *Builder {
+	if v.hasError() {
+		return v
+	}
+	value, ok := v.getString()
+	if ok {
+		v.validation.Add(v.field, IsHostname(value))
+	}
+	return v
+}
+
+// IsFQDN checks if the data is a fully qualified domain name, e.g. "api.example.com"
+func (v *Builder) IsFQDN() *Builder {
+	if v.hasError() {
+		return v
+	}
+	value, ok := v.getString()
+	if ok {
+		v.validation.Add(v.field, IsFQDN(value))
+	}
+	return v
+}
+
+// IsMAC checks if the data is a MAC address
+func (v *Builder) IsMAC() *Builder {
+	if v.hasError() {
+		return v
+	}
+	value, ok := v.getString()
+	if ok {
+		v.validation.Add(v.field, IsMAC(value))
+	}
+	return v
+}
+
+// IsPort checks if the data is a port number between 1 and 65535
+func (v *Builder) IsPort() *Builder {
+	if v.hasError() || !v.isSet() {
+		return v
+	}
+	value, ok := v.getInt()
+	if ok {
+		v.validation.Add(v.field, IsPort(value))
+	}
+	return v
+}
+
+// IPInRange checks if the data is an IP address in one of the given CIDRs
+//
+// It panics if a CIDR is invalid
+func (v *Builder) IPInRange(cidrs ...string) *Builder {
+	prefixes, err := parsePrefixes(cidrs)
+	if err != nil {
+		panic(err)
+	}
+	return v.ipInRange(cidrs, prefixes)
+}
+
+func (v *Builder) ipInRange(cidrs []string, prefixes []netip.Prefix) *Builder {
+	if v.hasError() {
+		return v
+	}
+	value, ok := v.getString()
+	if ok {
+		v.validation.Add(v.field, ipInRange(value, cidrs, prefixes))
+	}
+	return v
+}
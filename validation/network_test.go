@@ -0,0 +1,128 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNetworkValidators(t *testing.T) {
+	testCases := []struct {
+		validator Validator
+		expected  bool
+	}{
+		{IsURL("https://example.com/path?q=1"), true},
+		{IsURL("mailto:foo@example.com"), true},
+		{IsURL("mailto:foo@example.com", URLRequireHost()), false},
+		{IsURL("HTTPS://example.com", URLSchemes("http", "https")), true},
+		{IsURL("ftp://example.com", URLSchemes("http", "https")), false},
+		{IsURL("/relative/path"), false},
+		{IsURL("https://exa mple.com"), false},
+		{IsURL(""), false},
+		{IsIP("192.168.1.1"), true},
+		{IsIP("2001:db8::1"), true},
+		{IsIP("fe80::1%eth0"), false},
+		{IsIP("256.1.1.1"), false},
+		{IsIPv4("10.0.0.1"), true},
+		{IsIPv4("010.0.0.1"), false},
+		{IsIPv4("::1"), false},
+		{IsIPv6("::1"), true},
+		{IsIPv6("::ffff:10.0.0.1"), true},
+		{IsIPv6("10.0.0.1"), false},
+		{IsCIDR("10.0.0.0/8"), true},
+		{IsCIDR("2001:db8::/32"), true},
+		{IsCIDR("10.0.0.0/33"), false},
+		{IsCIDR("10.0.0.0"), false},
+		{IsHostname("localhost"), true},
+		{IsHostname("db-1.internal"), true},
+		{IsHostname("3com.com"), true},
+		{IsHostname("-db.internal"), false},
+		{IsHostname("db_1.internal"), false},
+		{IsHostname("db..internal"), false},
+		{IsHostname(strings.Repeat("a", 64) + ".com"), false},
+		{IsFQDN("api.example.com"), true},
+		{IsFQDN("api.example.com."), true},
+		{IsFQDN("localhost"), false},
+		{IsFQDN("10.0.0.1"), false},
+		{IsMAC("00:00:5e:00:53:01"), true},
+		{IsMAC("00-00-5E-00-53-01"), true},
+		{IsMAC("0000.5e00.5301"), true},
+		{IsMAC("00:00:5e:00:53"), false},
+		{IsPort(80), true},
+		{IsPort(uint16(65535)), true},
+		{IsPort(0), false},
+		{IsPort(-1), false},
+		{IsPort(65536), false},
+		{IPInRange("10.1.2.3", "10.0.0.0/8", "192.168.0.0/16"), true},
+		{IPInRange("::ffff:10.1.2.3", "10.0.0.0/8"), true},
+		{IPInRange("172.16.0.1", "10.0.0.0/8", "192.168.0.0/16"), false},
+		{IPInRange("not an ip", "10.0.0.0/8"), false},
+	}
+	for i, testCase := range testCases {
+		v := New()
+		v.Add("test", testCase.validator)
+		if testCase.expected && v.Error() != nil {
+			t.Fatalf(`case %d is invalid, it should be valid: %v`, i, v.Error())
+		}
+		if !testCase.expected && v.Error() == nil {
+			t.Fatalf(`case %d is valid, it should be invalid`, i)
+		}
+	}
+}
+
+func TestNetworkParams(t *testing.T) {
+	err := IsURL("ftp://example.com", URLSchemes("https"))("callback")
+	if err == nil || err.Tag() != "is_url" || len(err.Param("schemes").([]string)) != 1 {
+		t.Fatalf("expected is_url with the schemes param, got %v", err)
+	}
+	err = IPInRange("172.16.0.1", "10.0.0.0/8")("origin")
+	if err == nil || err.Tag() != "ip_in_range" || err.Param("ranges").([]string)[0] != "10.0.0.0/8" {
+		t.Fatalf("expected ip_in_range with the ranges param, got %v", err)
+	}
+}
+
+func TestNetworkBuilder(t *testing.T) {
+	v := New()
+	v.Builder("url", "https://example.com").IsURL(URLSchemes("https"), URLRequireHost())
+	v.Builder("ip", "10.0.0.1").IsIP().IsIPv4().IPInRange("10.0.0.0/8")
+	v.Builder("ipv6", "::1").IsIPv6()
+	v.Builder("cidr", "10.0.0.0/8").IsCIDR()
+	v.Builder("hostname", "db-1").IsHostname()
+	v.Builder("fqdn", "db-1.example.com").IsFQDN()
+	v.Builder("mac", "00:00:5e:00:53:01").IsMAC()
+	v.Builder("port", "8080").IsPort()
+	v.Builder("missing_port", (*string)(nil)).IsPort()
+	if err := v.Error(); err != nil {
+		t.Fatal("Expected error to be nil, got: ", err)
+	}
+
+	v = New()
+	v.Builder("port", "http").IsPort()
+	if err := v.Error(); err == nil || err.(Error).Errors()["port"].Tag() != "invalid_integer" {
+		t.Fatalf("expected invalid_integer, got %v", err)
+	}
+}
+
+func TestNetworkRules(t *testing.T) {
+	v := New()
+	v.Builder("url", "https://example.com").Rules("is_url:https,host")
+	v.Builder("ip", "10.0.0.1").Rules("is_ip|is_ipv4|ip_in_range:10.0.0.0/8,192.168.0.0/16")
+	v.Builder("port", 443).Rules("is_port")
+	v.Builder("host", "example.com").Rules("is_hostname|is_fqdn")
+	if err := v.Error(); err != nil {
+		t.Fatal("Expected error to be nil, got: ", err)
+	}
+
+	v = New()
+	v.Builder("url", "mailto:foo@example.com").Rules("is_url:host")
+	v.Builder("ip", "172.16.0.1").Rules("ip_in_range:10.0.0.0/8")
+	if err := v.Error(); err == nil || len(err.(Error).Errors()) != 2 {
+		t.Fatalf("expected 2 errors, got %v", err)
+	}
+
+	if _, err := CompileRuleSet("ip_in_range:10.0.0.0/33"); err == nil {
+		t.Fatal("Expected error to be not nil")
+	}
+	if _, err := CompileRuleSet("ip_in_range"); err == nil {
+		t.Fatal("Expected error to be not nil")
+	}
+}
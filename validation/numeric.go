@@ -0,0 +1,342 @@
+package validation
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// multipleTolerance is how far from a whole number the quotient of two floats can be
+// and still count as a multiple, so that 0.3 is a multiple of 0.1
+const multipleTolerance = 1e-9
+
+// multipleRoundingUlps is how many units in the last place the quotient of two floats
+// may be off by rounding, which matters for large quotients
+const multipleRoundingUlps = 4
+
+// isNaN reports whether the number is NaN, numbers other than floats are never NaN
+func isNaN[T Number](data T) bool {
+	return math.IsNaN(float64(data))
+}
+
+// isMultiple reports whether data is a whole multiple of step. Floats are compared with
+// a tolerance, integers exactly
+func isMultiple[T Number](data T, step T) bool {
+	if step == 0 {
+		return data == 0
+	}
+	quotient := data / step
+	var half T = 1
+	if half /= 2; half == 0 {
+		// integer division truncates, so only exact multiples give back data
+		return quotient*step == data
+	}
+	// the rounding error of the quotient grows with it, but only by the precision of T,
+	// so 1000000000.5 is not a multiple of 1
+	epsilon := 0x1p-52
+	if tiny := 0x1p-30; T(1)+T(tiny) == T(1) {
+		epsilon = 0x1p-23 // float32
+	}
+	q := float64(quotient)
+	return math.Abs(q-math.Round(q)) <= multipleTolerance+multipleRoundingUlps*epsilon*math.Abs(q)
+}
+
+// MultipleOf checks if the data is a multiple of step, e.g. a quantity sold by the dozen.
+// Floats are compared with a small tolerance, so 0.3 is a multiple of 0.1
+//
+// Has one parameter: multiple_of (same type as data)
+func MultipleOf[T Number](data T, step T) Validator {
+	return func(field string) *FieldError {
+		if !isMultiple(data, step) {
+			msg := fmt.Sprintf("%s must be a multiple of %v", field, step)
+			err := NewFieldError(field, msg, "multiple_of", data)
+			err.SetParam("multiple_of", step)
+			return err
+		}
+		return nil
+	}
+}
+
+// Step checks if the data is base plus a multiple of step, like the step of an HTML
+// number input, e.g. 0.5, 1.0 and 1.5 with a step of 0.5 and a base of 0
+//
+// Has two parameters: step, base (same type as data)
+func Step[T Number](data T, step T, base T) Validator {
+	return func(field string) *FieldError {
+		offset := data - base
+		if data < base {
+			// unsigned integers would wrap around below base
+			offset = base - data
+		}
+		if !isMultiple(offset, step) {
+			msg := fmt.Sprintf("%s must be %v plus a multiple of %v", field, base, step)
+			err := NewFieldError(field, msg, "step", data)
+			err.SetParam("step", step)
+			err.SetParam("base", base)
+			return err
+		}
+		return nil
+	}
+}
+
+// Positive checks if the data is greater than 0
+func Positive[T Number](data T) Validator {
+	return func(field string) *FieldError {
+		if !(data > 0) {
+			msg := fmt.Sprintf("%s must be positive", field)
+			return NewFieldError(field, msg, "positive", data)
+		}
+		return nil
+	}
+}
+
+// Negative checks if the data is less than 0
+func Negative[T Number](data T) Validator {
+	return func(field string) *FieldError {
+		if !(data < 0) {
+			msg := fmt.Sprintf("%s must be negative", field)
+			return NewFieldError(field, msg, "negative", data)
+		}
+		return nil
+	}
+}
+
+// NonNegative checks if the data is 0 or greater
+func NonNegative[T Number](data T) Validator {
+	return func(field string) *FieldError {
+		if !(data >= 0) {
+			msg := fmt.Sprintf("%s must not be negative", field)
+			return NewFieldError(field, msg, "non_negative", data)
+		}
+		return nil
+	}
+}
+
+// NonZero checks if the data is not 0
+func NonZero[T Number](data T) Validator {
+	return func(field string) *FieldError {
+		if data == 0 {
+			msg := fmt.Sprintf("%s must not be zero", field)
+			return NewFieldError(field, msg, "non_zero", data)
+		}
+		return nil
+	}
+}
+
+// Finite checks if the data is neither NaN nor an infinity, integers are always finite
+func Finite[T Number](data T) Validator {
+	return func(field string) *FieldError {
+		if f := float64(data); math.IsNaN(f) || math.IsInf(f, 0) {
+			msg := fmt.Sprintf("%s must be a finite number", field)
+			return NewFieldError(field, msg, "finite", data)
+		}
+		return nil
+	}
+}
+
+// decimalDigits counts the digits of a decimal number like "-1234.50", before and after
+// the decimal point. Leading zeros are not counted, trailing zeros are
+func decimalDigits(data string) (integer int, fraction int, ok bool) {
+	if strings.HasPrefix(data, "+") || strings.HasPrefix(data, "-") {
+		data = data[1:]
+	}
+	whole, frac, _ := strings.Cut(data, ".")
+	if whole == "" && frac == "" {
+		return 0, 0, false
+	}
+	for _, part := range []string{whole, frac} {
+		for _, c := range part {
+			if c < '0' || c > '9' {
+				return 0, 0, false
+			}
+		}
+	}
+	return len(strings.TrimLeft(whole, "0")), len(frac), true
+}
+
+// Digits checks if the data is a decimal number with at most precision digits, of which
+// at most scale are after the decimal point, like a DECIMAL(precision, scale) column,
+// e.g. "1234.50" has a precision of 6 and a scale of 2
+//
+// Has two parameters: precision (int), scale (int)
+func Digits(data string, precision int, scale int) Validator {
+	return func(field string) *FieldError {
+		integer, fraction, ok := decimalDigits(data)
+		if !ok || integer > precision-scale || fraction > scale {
+			return digitsError(field, data, precision, scale)
+		}
+		return nil
+	}
+}
+
+// DigitsNumber checks if the data has at most precision digits, of which at most scale are
+// after the decimal point, see Digits. Floats are written in their shortest form, so
+// 0.1 has one decimal place, and NaN and infinities are invalid
+//
+// Has two parameters: precision (int), scale (int)
+func DigitsNumber[T Number](data T, precision int, scale int) Validator {
+	return func(field string) *FieldError {
+		integer, fraction, _ := decimalDigits(formatNumber(data))
+		if isNaN(data) || math.IsInf(float64(data), 0) || integer > precision-scale || fraction > scale {
+			return digitsError(field, data, precision, scale)
+		}
+		return nil
+	}
+}
+
+func digitsError(field string, value interface{}, precision int, scale int) *FieldError {
+	msg := fmt.Sprintf("%s must be a number with at most %d digits and %d decimal places", field, precision, scale)
+	err := NewFieldError(field, msg, "digits", value)
+	err.SetParam("precision", precision)
+	err.SetParam("scale", scale)
+	return err
+}
+
+// formatNumber writes the number as a decimal without exponent
+func formatNumber[T Number](data T) string {
+	switch kind := reflect.ValueOf(data).Kind(); kind {
+	case reflect.Float32, reflect.Float64:
+		bitSize := 64
+		if kind == reflect.Float32 {
+			bitSize = 32
+		}
+		return strconv.FormatFloat(float64(data), 'f', -1, bitSize)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(uint64(data), 10)
+	default:
+		return strconv.FormatInt(int64(data), 10)
+	}
+}
+
+// MultipleOf checks if the data is a multiple of step. Exact numbers and decimal strings
+// are checked exactly against the decimal form of step, e.g. 0.01, like the multiple_of rule,
+// see MultipleOfDecimal
+//
+// Has one parameter: step (float64)
+func (v *Builder) MultipleOf(step float64) *Builder {
+	if v.hasError() {
+		return v
+	}
+	if v.isDecimal() {
+		// the shortest decimal form, as 0.01 has no exact float64
+		if decimal, err := ParseDecimal(strconv.FormatFloat(step, 'g', -1, 64)); err == nil {
+			return v.MultipleOfDecimal(decimal)
+		}
+	}
+	value, ok := v.getFloat()
+	if ok {
+		v.validation.Add(v.field, MultipleOf(value, step))
+	}
+	return v
+}
+
+// Step checks if the data is base plus a multiple of step
+//
+// Has two parameters: step, base (float64)
+func (v *Builder) Step(step, base float64) *Builder {
+	if v.hasError() {
+		return v
+	}
+	value, ok := v.getFloat()
+	if ok {
+		v.validation.Add(v.field, Step(value, step, base))
+	}
+	return v
+}
+
+// Positive checks if the data is greater than 0
+func (v *Builder) Positive() *Builder {
+	if v.hasError() {
+		return v
+	}
+	value, ok := v.getFloat()
+	if ok {
+		v.validation.Add(v.field, Positive(value))
+	}
+	return v
+}
+
+// Negative checks if the data is less than 0
+func (v *Builder) Negative() *Builder {
+	if v.hasError() {
+		return v
+	}
+	value, ok := v.getFloat()
+	if ok {
+		v.validation.Add(v.field, Negative(value))
+	}
+	return v
+}
+
+// NonNegative checks if the data is 0 or greater
+func (v *Builder) NonNegative() *Builder {
+	if v.hasError() {
+		return v
+	}
+	value, ok := v.getFloat()
+	if ok {
+		v.validation.Add(v.field, NonNegative(value))
+	}
+	return v
+}
+
+// NonZero checks if the data is not 0
+func (v *Builder) NonZero() *Builder {
+	if v.hasError() {
+		return v
+	}
+	value, ok := v.getFloat()
+	if ok {
+		v.validation.Add(v.field, NonZero(value))
+	}
+	return v
+}
+
+// Finite checks if the data is neither NaN nor an infinity
+func (v *Builder) Finite() *Builder {
+	if v.hasError() {
+		return v
+	}
+	value, ok := v.getFloat()
+	if ok {
+		v.validation.Add(v.field, Finite(value))
+	}
+	return v
+}
+
+// Digits checks if the data has at most precision digits, of which at most scale are after
+// the decimal point. Strings are checked as written, so "1.50" has two decimal places
+//
+// Has two parameters: precision (int), scale (int)
+func (v *Builder) Digits(precision, scale int) *Builder {
+	if v.hasError() {
+		return v
+	}
+	switch val := v.value.(type) {
+	case float32:
+		v.validation.Add(v.field, DigitsNumber(val, precision, scale))
+	case *float32:
+		if val != nil {
+			v.validation.Add(v.field, DigitsNumber(*val, precision, scale))
+		}
+	case float64, *float64:
+		if value, ok := v.getFloat(); ok {
+			v.validation.Add(v.field, DigitsNumber(value, precision, scale))
+		}
+	case int, int8, int16, int32, int64, *int, *int8, *int16, *int32, *int64:
+		if value, ok := v.getInt(); ok {
+			v.validation.Add(v.field, DigitsNumber(value, precision, scale))
+		}
+	case uint, uint8, uint16, uint32, uint64, *uint, *uint8, *uint16, *uint32, *uint64:
+		if value, ok := v.getUint(); ok {
+			v.validation.Add(v.field, DigitsNumber(value, precision, scale))
+		}
+	default:
+		if value, ok := v.getString(); ok {
+			v.validation.Add(v.field, Digits(value, precision, scale))
+		}
+	}
+	return v
+}
@@ -0,0 +1,168 @@
+package validation
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNumberConstraints(t *testing.T) {
+	nan, inf := math.NaN(), math.Inf(1)
+	testCases := []struct {
+		validator Validator
+		tag       string
+	}{
+		{MultipleOf(12, 3), ""},
+		{MultipleOf(-12, 3), ""},
+		{MultipleOf(13, 3), "multiple_of"},
+		{MultipleOf(uint8(200), 8), ""},
+		{MultipleOf(0.3, 0.1), ""},
+		{MultipleOf(1.15, 0.05), ""},
+		{MultipleOf(float32(0.3), 0.1), ""},
+		{MultipleOf(1.001, 0.01), "multiple_of"},
+		{MultipleOf(1000000000.5, 1.0), "multiple_of"},
+		{MultipleOf(5000000.005, 0.01), "multiple_of"},
+		{MultipleOf(123456789.75, 0.25), ""},
+		{MultipleOf(5000000.01, 0.01), ""},
+		{MultipleOf(12345678901.23, 0.01), ""},
+		{MultipleOf(12345678901.235, 0.01), "multiple_of"},
+		{MultipleOf(float32(100000.5), 1), "multiple_of"},
+		{MultipleOf(0, 0), ""},
+		{MultipleOf(5, 0), "multiple_of"},
+		{MultipleOf(nan, 0.1), "multiple_of"},
+		{MultipleOf(inf, 0.1), "multiple_of"},
+		{Step(1.5, 0.5, 0), ""},
+		{Step(1.6, 0.5, 0.1), ""},
+		{Step(1.5, 0.5, 0.1), "step"},
+		{Step(uint(4), 3, 5), "step"},
+		{Step(uint(2), 3, 5), ""},
+		{Step(nan, 0.5, 0), "step"},
+		{Positive(1), ""},
+		{Positive(0), "positive"},
+		{Positive(nan), "positive"},
+		{Negative(-0.5), ""},
+		{Negative(uint(0)), "negative"},
+		{NonNegative(0), ""},
+		{NonNegative(-1), "non_negative"},
+		{NonNegative(nan), "non_negative"},
+		{NonZero(-1), ""},
+		{NonZero(0.0), "non_zero"},
+		{Finite(1e308), ""},
+		{Finite(42), ""},
+		{Finite(nan), "finite"},
+		{Finite(-inf), "finite"},
+		{Min(nan, 0), "min"},
+		{Max(nan, 0), "max"},
+		{Range(nan, 0, 1), "range"},
+		{Range(inf, 0, 1), "range"},
+	}
+	for i, testCase := range testCases {
+		err := testCase.validator("amount")
+		if testCase.tag == "" && err != nil {
+			t.Fatalf(`case %d is invalid, it should be valid: %v`, i, err)
+		}
+		if testCase.tag != "" && (err == nil || err.Tag() != testCase.tag) {
+			t.Fatalf(`case %d should be invalid with %s, got %v`, i, testCase.tag, err)
+		}
+	}
+}
+
+func TestDigits(t *testing.T) {
+	testCases := []struct {
+		value     string
+		precision int
+		scale     int
+		valid     bool
+	}{
+		{"1234.56", 6, 2, true},
+		{"-1234.5", 6, 2, true},
+		{"+0001234", 6, 2, true},
+		{"0.99", 2, 2, true},
+		{".5", 2, 2, true},
+		{"12345.6", 6, 2, false},
+		{"1.234", 6, 2, false},
+		{"1.230", 6, 2, false},
+		{"1", 0, 0, false},
+		{"", 6, 2, false},
+		{"-", 6, 2, false},
+		{".", 6, 2, false},
+		{"1e3", 6, 2, false},
+		{"1,5", 6, 2, false},
+		{"--1", 6, 2, false},
+	}
+	for _, testCase := range testCases {
+		err := Digits(testCase.value, testCase.precision, testCase.scale)("amount")
+		if testCase.valid && err != nil {
+			t.Fatalf(`value %q is invalid, it should be valid`, testCase.value)
+		}
+		if !testCase.valid && (err == nil || err.Tag() != "digits") {
+			t.Fatalf(`value %q is valid, it should be invalid`, testCase.value)
+		}
+	}
+
+	numbers := []struct {
+		validator Validator
+		valid     bool
+	}{
+		{DigitsNumber(1234.5, 6, 2), true},
+		{DigitsNumber(0.1, 2, 1), true},
+		{DigitsNumber(float32(0.1), 2, 1), true},
+		{DigitsNumber(1e21, 30, 2), true},
+		{DigitsNumber(0.125, 6, 2), false},
+		{DigitsNumber(1e7, 6, 2), false},
+		{DigitsNumber(9999, 4, 0), true},
+		{DigitsNumber(uint64(math.MaxUint64), 20, 0), true},
+		{DigitsNumber(int8(-128), 2, 0), false},
+		{DigitsNumber(math.NaN(), 6, 2), false},
+		{DigitsNumber(math.Inf(-1), 6, 2), false},
+	}
+	for i, testCase := range numbers {
+		err := testCase.validator("amount")
+		if testCase.valid && err != nil {
+			t.Fatalf(`case %d is invalid, it should be valid: %v`, i, err)
+		}
+		if !testCase.valid && err == nil {
+			t.Fatalf(`case %d is valid, it should be invalid`, i)
+		}
+	}
+
+	err := Digits("1.234", 6, 2)("amount")
+	if err.Param("precision") != 6 || err.Param("scale") != 2 {
+		t.Fatalf("expected the precision and scale params, got %v", err.Params())
+	}
+}
+
+func TestNumericBuilder(t *testing.T) {
+	price := float32(19.99)
+	var missing *float64
+	v := New()
+	v.Builder("quantity", 24).MultipleOf(12).Positive().NonZero()
+	v.Builder("price", &price).Digits(6, 2).Finite()
+	v.Builder("missing", missing).Positive().Digits(6, 2)
+	v.Builder("amount", "1500.50").Digits(10, 2).Step(0.25, 0).NonNegative()
+	v.Builder("total", int64(123456)).Digits(6, 0)
+	v.Builder("rules", "-0.75").Rules("finite|negative|non_zero|multiple_of:0.25|step:0.5,0.25|digits:4,2")
+	if err := v.Error(); err != nil {
+		t.Fatal("Expected error to be nil, got: ", err)
+	}
+
+	v = New()
+	v.Builder("amount", "10.505").Digits(10, 2)
+	v.Builder("nan", "NaN").Finite()
+	v.Builder("inf", "+Inf").Rules("max:100")
+	v.Builder("quantity", "abc").Positive()
+	v.Builder("step", 1.3).Rules("step:0.5")
+	errs := v.Error().(Error).Errors()
+	expected := map[string]string{"amount": "digits", "nan": "finite", "inf": "max", "quantity": "invalid_float", "step": "step"}
+	for field, tag := range expected {
+		if errs[field] == nil || errs[field].Tag() != tag {
+			t.Fatalf("expected %s to be invalid with %s, got %v", field, tag, errs[field])
+		}
+	}
+
+	invalidRules := []string{"multiple_of", "multiple_of:x", "step:1,2,3", "digits:2", "digits:2,3", "digits:4,-1", "positive:1"}
+	for _, rules := range invalidRules {
+		if _, err := CompileRuleSet(rules); err == nil {
+			t.Fatalf(`rule %q is valid, it should be invalid`, rules)
+		}
+	}
+}
@@ -0,0 +1,337 @@
+package validation
+
+import (
+	_ "embed"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// PasswordPolicy is the set of requirements checked by Password, zero fields are not checked
+type PasswordPolicy struct {
+	// MinLength is the minimum number of characters, counted as runes
+	MinLength     int
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+	// MaxRepeated is the maximum number of times a character can be repeated in a row,
+	// e.g. 2 rejects "aaa"
+	MaxRepeated int
+	// MaxSequence is the length of the longest allowed sequence of letters, digits or keys
+	// of a keyboard row, in any direction, e.g. 3 rejects "abcd", "4321" and "qwer"
+	MaxSequence int
+	// BlockCommon rejects the common passwords, see IsCommonPassword
+	BlockCommon bool
+	// MinScore is the minimum strength score, from 0 to 4, see PasswordResult
+	MinScore int
+}
+
+// DefaultPasswordPolicy is the policy of the password rule
+var DefaultPasswordPolicy = PasswordPolicy{MinLength: 8, BlockCommon: true, MinScore: 2}
+
+// Tags of the requirements of Password
+const (
+	PasswordMinLength = "password_min_length"
+	PasswordUpper     = "password_upper"
+	PasswordLower     = "password_lower"
+	PasswordDigit     = "password_digit"
+	PasswordSymbol    = "password_symbol"
+	PasswordRepeated  = "password_repeated"
+	PasswordSequence  = "password_sequence"
+	PasswordUserInput = "password_user_input"
+	PasswordCommon    = "password_common"
+	PasswordScore     = "password_score"
+)
+
+var passwordMessages = map[string]string{
+	PasswordMinLength: "%s is too short",
+	PasswordUpper:     "%s must contain an uppercase letter",
+	PasswordLower:     "%s must contain a lowercase letter",
+	PasswordDigit:     "%s must contain a digit",
+	PasswordSymbol:    "%s must contain a symbol",
+	PasswordRepeated:  "%s has too many repeated characters",
+	PasswordSequence:  "%s contains a sequence of characters",
+	PasswordUserInput: "%s must not contain your personal information",
+	PasswordCommon:    "%s is too common",
+	PasswordScore:     "%s is too weak",
+}
+
+// PasswordResult is the result of CheckPassword
+type PasswordResult struct {
+	// Unmet are the tags of the requirements of the policy that are not met, in the order
+	// of the Password constants
+	Unmet []string
+	// Entropy is the estimated entropy of the password in bits
+	Entropy float64
+	// Score is the strength of the password from 0, too guessable, to 4, very unguessable.
+	// Common passwords have a score of 0
+	Score int
+}
+
+// common_passwords.txt contains commonly used passwords, one per line
+//
+//go:embed common_passwords.txt
+var commonPasswordsFile string
+
+var (
+	commonPasswordsMu sync.RWMutex
+	commonPasswords   = mustReadList(commonPasswordsFile)
+)
+
+// IsCommonPassword reports whether password is a commonly used password, ignoring case
+//
+// The list is embedded from common_passwords.txt and can be changed with
+// SetCommonPasswords and LoadCommonPasswords
+func IsCommonPassword(password string) bool {
+	commonPasswordsMu.RLock()
+	defer commonPasswordsMu.RUnlock()
+	return commonPasswords[strings.ToLower(password)]
+}
+
+// SetCommonPasswords replaces the list of common passwords
+func SetCommonPasswords(passwords []string) {
+	set := make(map[string]bool, len(passwords))
+	for _, password := range passwords {
+		set[strings.ToLower(password)] = true
+	}
+	commonPasswordsMu.Lock()
+	defer commonPasswordsMu.Unlock()
+	commonPasswords = set
+}
+
+// LoadCommonPasswords replaces the list of common passwords with the passwords read from r,
+// one per line. Empty lines and lines starting with "#" are ignored
+func LoadCommonPasswords(r io.Reader) error {
+	passwords, err := readList(r)
+	if err != nil {
+		return err
+	}
+	SetCommonPasswords(passwords)
+	return nil
+}
+
+// passwordSequences are the sequences checked by PasswordPolicy.MaxSequence, they are
+// also checked reversed
+var passwordSequences = []string{
+	"abcdefghijklmnopqrstuvwxyz",
+	"01234567890",
+	"qwertyuiop",
+	"asdfghjkl",
+	"zxcvbnm",
+}
+
+// CheckPassword checks password against policy, and estimates its strength
+//
+// userInputs are values the password must not contain, like the username or the email
+// address, they are compared ignoring case. The local part of email addresses and the
+// words of the inputs with at least 3 characters are checked too
+func CheckPassword(password string, policy PasswordPolicy, userInputs ...string) PasswordResult {
+	var hasUpper, hasLower, hasDigit, hasSymbol, hasOther bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case r < utf8.RuneSelf || unicode.IsPunct(r) || unicode.IsSymbol(r):
+			hasSymbol = true
+		default:
+			hasOther = true
+		}
+	}
+	lower := strings.ToLower(password)
+	common := IsCommonPassword(password)
+	userInput := containsUserInput(lower, userInputs)
+
+	result := PasswordResult{}
+	result.Entropy = passwordEntropy(password, hasUpper, hasLower, hasDigit, hasSymbol, hasOther)
+	if userInput != "" {
+		result.Entropy = math.Max(0, result.Entropy*(1-float64(len(userInput))/float64(len(lower))))
+	}
+	result.Score = passwordScore(result.Entropy)
+	if common {
+		result.Score = 0
+	}
+
+	unmet := func(requirement string, failed bool) {
+		if failed {
+			result.Unmet = append(result.Unmet, requirement)
+		}
+	}
+	unmet(PasswordMinLength, utf8.RuneCountInString(password) < policy.MinLength)
+	unmet(PasswordUpper, policy.RequireUpper && !hasUpper)
+	unmet(PasswordLower, policy.RequireLower && !hasLower)
+	unmet(PasswordDigit, policy.RequireDigit && !hasDigit)
+	unmet(PasswordSymbol, policy.RequireSymbol && !hasSymbol)
+	unmet(PasswordRepeated, policy.MaxRepeated > 0 && longestRepeat(password) > policy.MaxRepeated)
+	unmet(PasswordSequence, policy.MaxSequence > 0 && hasSequence(lower, policy.MaxSequence+1))
+	unmet(PasswordUserInput, userInput != "")
+	unmet(PasswordCommon, policy.BlockCommon && common)
+	unmet(PasswordScore, result.Score < policy.MinScore)
+	return result
+}
+
+// passwordEntropy estimates the entropy of password from the size of the pool of its characters,
+// a character repeating the previous one or continuing a sequence with it only counts for a quarter
+func passwordEntropy(password string, hasUpper, hasLower, hasDigit, hasSymbol, hasOther bool) float64 {
+	pool := 0
+	for _, class := range []struct {
+		present bool
+		size    int
+	}{{hasUpper, 26}, {hasLower, 26}, {hasDigit, 10}, {hasSymbol, 33}, {hasOther, 100}} {
+		if class.present {
+			pool += class.size
+		}
+	}
+	if pool == 0 {
+		return 0
+	}
+	length := 0.0
+	prev := rune(-1)
+	for _, r := range password {
+		r = unicode.ToLower(r)
+		if r == prev || isSequenceStep(prev, r) {
+			length += 0.25
+		} else {
+			length++
+		}
+		prev = r
+	}
+	return length * math.Log2(float64(pool))
+}
+
+// passwordScore turns entropy into a score from 0 to 4
+func passwordScore(entropy float64) int {
+	switch {
+	case entropy < 28:
+		return 0
+	case entropy < 36:
+		return 1
+	case entropy < 60:
+		return 2
+	case entropy < 80:
+		return 3
+	}
+	return 4
+}
+
+func longestRepeat(password string) int {
+	longest, run := 0, 0
+	prev := rune(-1)
+	for _, r := range password {
+		if r == prev {
+			run++
+		} else {
+			run = 1
+		}
+		if run > longest {
+			longest = run
+		}
+		prev = r
+	}
+	return longest
+}
+
+// hasSequence reports whether lower contains n consecutive characters of a password sequence
+func hasSequence(lower string, n int) bool {
+	for _, sequence := range passwordSequences {
+		for _, s := range []string{sequence, reverse(sequence)} {
+			for i := 0; i+n <= len(s); i++ {
+				if strings.Contains(lower, s[i:i+n]) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// isSequenceStep reports whether r follows or precedes prev in a password sequence
+func isSequenceStep(prev, r rune) bool {
+	for _, sequence := range passwordSequences {
+		for i := 1; i < len(sequence); i++ {
+			a, b := rune(sequence[i-1]), rune(sequence[i])
+			if prev == a && r == b || prev == b && r == a {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func reverse(s string) string {
+	b := []byte(s)
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+	return string(b)
+}
+
+// containsUserInput returns the longest user input, or word of a user input, contained in lower
+func containsUserInput(lower string, userInputs []string) string {
+	found := ""
+	check := func(token string) {
+		if utf8.RuneCountInString(token) >= 3 && len(token) > len(found) && strings.Contains(lower, token) {
+			found = token
+		}
+	}
+	for _, input := range userInputs {
+		input = strings.ToLower(strings.TrimSpace(input))
+		check(input)
+		if at := strings.LastIndexByte(input, '@'); at > 0 {
+			check(input[:at])
+		}
+		for _, word := range strings.FieldsFunc(input, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}) {
+			check(word)
+		}
+	}
+	return found
+}
+
+// Password checks if the data meets the requirements of policy, see CheckPassword
+//
+// The tag is the tag of the first unmet requirement, one of the Password constants.
+// The password is not kept as the value of the error so it can't leak into logs.
+// Has two parameters: requirements ([]string), the tags of all the unmet requirements,
+// and score (int), the strength of the password from 0 to 4
+func Password(password string, policy PasswordPolicy, userInputs ...string) Validator {
+	return func(field string) *FieldError {
+		result := CheckPassword(password, policy, userInputs...)
+		if len(result.Unmet) == 0 {
+			return nil
+		}
+		tag := result.Unmet[0]
+		msg := fmt.Sprintf(passwordMessages[tag], field)
+		err := NewFieldError(field, msg, tag, nil)
+		err.SetParam("requirements", result.Unmet)
+		err.SetParam("score", result.Score)
+		if tag == PasswordMinLength {
+			err.SetParam("min", policy.MinLength)
+		}
+		return err
+	}
+}
+
+// Password checks if the data meets the requirements of policy
+//
+// Has two parameters: policy (PasswordPolicy), and userInputs (...string), values the
+// password must not contain, like the username or the email address
+func (v *Builder) Password(policy PasswordPolicy, userInputs ...string) *Builder {
+	if v.hasError() {
+		return v
+	}
+	value, ok := v.getString()
+	if ok {
+		v.validation.Add(v.field, Password(value, policy, userInputs...))
+	}
+	return v
+}
@@ -0,0 +1,120 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckPassword(t *testing.T) {
+	policy := PasswordPolicy{
+		MinLength:     10,
+		RequireUpper:  true,
+		RequireLower:  true,
+		RequireDigit:  true,
+		RequireSymbol: true,
+		MaxRepeated:   2,
+		MaxSequence:   3,
+		BlockCommon:   true,
+		MinScore:      3,
+	}
+	testCases := []struct {
+		password   string
+		userInputs []string
+		unmet      []string
+	}{
+		{"Tr0ub4dor&3x", nil, nil},
+		{"Zoë-Ünïcødé-7", nil, nil},
+		{"Abc!Abc1Ab", nil, []string{PasswordScore}},
+		{"Tr0ub&", nil, []string{PasswordMinLength, PasswordScore}},
+		{"tr0ub4dor&3x", nil, []string{PasswordUpper}},
+		{"TR0UB4DOR&3X", nil, []string{PasswordLower}},
+		{"Troubador&xy", nil, []string{PasswordDigit}},
+		{"Tr0ub4dor33x", nil, []string{PasswordSymbol}},
+		{"Tr0ub4dooor&3x", nil, []string{PasswordRepeated}},
+		{"Tr0ub4dor&3xAbcd", nil, []string{PasswordSequence}},
+		{"Tr0ub4dor&3x4321", nil, []string{PasswordSequence}},
+		{"Tr0ub4dor&3xQwer", nil, []string{PasswordSequence}},
+		{"Tr0ub4dor&3xJohnDoe", []string{"john.doe@example.com"}, []string{PasswordUserInput}},
+		{"Doe-Tr0ub4dor&3x", []string{"Jo", "Jane Doe"}, []string{PasswordUserInput}},
+		{"password", nil, []string{PasswordMinLength, PasswordUpper, PasswordDigit, PasswordSymbol, PasswordCommon, PasswordScore}},
+		{"P@ssw0rd", nil, []string{PasswordMinLength, PasswordCommon, PasswordScore}},
+	}
+	for _, testCase := range testCases {
+		result := CheckPassword(testCase.password, policy, testCase.userInputs...)
+		if strings.Join(result.Unmet, ",") != strings.Join(testCase.unmet, ",") {
+			t.Fatalf(`value %q should fail %v, got %v (score %d)`, testCase.password, testCase.unmet, result.Unmet, result.Score)
+		}
+	}
+}
+
+func TestPasswordScore(t *testing.T) {
+	testCases := []struct {
+		password string
+		score    int
+	}{
+		{"", 0},
+		{"abc", 0},
+		{"aaaaaaaaaaaa", 0},
+		{"monkey", 0},
+		{"qwerty123456", 0},
+		{"sunny42", 1},
+		{"kitten42", 2},
+		{"blue-Kitten", 3},
+		{"Tr0ub4dor&3x", 3},
+		{"correcthorsebatterystaple", 4},
+	}
+	for _, testCase := range testCases {
+		if result := CheckPassword(testCase.password, PasswordPolicy{}); result.Score != testCase.score {
+			t.Fatalf(`value %q should have score %d, got %d (%.1f bits)`, testCase.password, testCase.score, result.Score, result.Entropy)
+		}
+	}
+}
+
+func TestPassword(t *testing.T) {
+	if err := Password("correct horse battery", DefaultPasswordPolicy)("password"); err != nil {
+		t.Fatal("Expected error to be nil, got: ", err)
+	}
+	err := Password("Password", PasswordPolicy{MinLength: 12, BlockCommon: true})("password")
+	if err == nil || err.Tag() != PasswordMinLength || err.Value() != nil {
+		t.Fatalf("expected a password_min_length error without the value, got %v", err)
+	}
+	requirements := err.Param("requirements").([]string)
+	if len(requirements) != 2 || requirements[1] != PasswordCommon || err.Param("score") != 0 || err.Param("min") != 12 {
+		t.Fatalf("expected the min length and common requirements with a score of 0, got %v", err.Params())
+	}
+}
+
+func TestCommonPasswords(t *testing.T) {
+	defer LoadCommonPasswords(strings.NewReader(commonPasswordsFile))
+
+	if !IsCommonPassword("123456") || !IsCommonPassword("QWERTY") || IsCommonPassword("Tr0ub4dor&3x") {
+		t.Fatal("expected the embedded list to be used")
+	}
+	if err := LoadCommonPasswords(strings.NewReader("# comment\n\nHunter2\n")); err != nil {
+		t.Fatal("Expected error to be nil, got: ", err)
+	}
+	if !IsCommonPassword("hunter2") || IsCommonPassword("123456") {
+		t.Fatal("expected the loaded list to replace the embedded list")
+	}
+}
+
+func TestPasswordBuilder(t *testing.T) {
+	v := New()
+	v.Builder("password", "Tr0ub4dor&3x").Password(PasswordPolicy{MinLength: 10, RequireDigit: true}, "john")
+	v.Builder("rules", "correct horse battery").Rules("password:12")
+	if err := v.Error(); err != nil {
+		t.Fatal("Expected error to be nil, got: ", err)
+	}
+
+	v = New()
+	v.Builder("password", "john1234").Password(PasswordPolicy{}, "John")
+	v.Builder("rules", "letmein").Rules("password")
+	errs := v.Error().(Error).Errors()
+	if errs["password"].Tag() != PasswordUserInput || errs["rules"].Tag() != PasswordMinLength {
+		t.Fatalf("expected password_user_input and password_min_length, got %v", errs)
+	}
+
+	if _, err := CompileRuleSet("password:x"); err == nil {
+		t.Fatal("Expected error to be not nil")
+	}
+}
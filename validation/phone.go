@@ -0,0 +1,294 @@
+package validation
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// PhoneType is the type of line of a phone number
+type PhoneType int
+
+const (
+	PhoneUnknown PhoneType = iota
+	PhoneFixedLine
+	PhoneMobile
+	// PhoneFixedLineOrMobile is used for regions where fixed line and mobile numbers
+	// can't be told apart, like the US
+	PhoneFixedLineOrMobile
+)
+
+var phoneTypeNames = map[PhoneType]string{
+	PhoneUnknown:           "unknown",
+	PhoneFixedLine:         "fixed_line",
+	PhoneMobile:            "mobile",
+	PhoneFixedLineOrMobile: "fixed_line_or_mobile",
+}
+
+// String returns the name of the phone type, e.g. "mobile"
+func (t PhoneType) String() string {
+	if name, ok := phoneTypeNames[t]; ok {
+		return name
+	}
+	return phoneTypeNames[PhoneUnknown]
+}
+
+// ParsePhoneType parses the name of a phone type, e.g. "mobile"
+func ParsePhoneType(name string) (PhoneType, error) {
+	for phoneType, typeName := range phoneTypeNames {
+		if typeName == name && phoneType != PhoneUnknown {
+			return phoneType, nil
+		}
+	}
+	return PhoneUnknown, fmt.Errorf("validation: unknown phone type %q", name)
+}
+
+// matches reports whether a number of type t can be a number of type expected
+func (t PhoneType) matches(expected PhoneType) bool {
+	if t == expected {
+		return true
+	}
+	return t == PhoneFixedLineOrMobile && (expected == PhoneFixedLine || expected == PhoneMobile)
+}
+
+var (
+	// ErrPhoneRegion is returned when the region of a phone number is unknown or not supported
+	ErrPhoneRegion = errors.New("validation: unsupported phone region")
+	// ErrPhoneLength is returned when a phone number is too short or too long for its region
+	ErrPhoneLength = errors.New("validation: invalid phone number length")
+	// ErrPhoneNumber is returned when a phone number doesn't match the numbering plan of its region
+	ErrPhoneNumber = errors.New("validation: invalid phone number")
+)
+
+// PhoneNumber is a parsed phone number
+type PhoneNumber struct {
+	// Region is the ISO 3166-1 alpha-2 code of the region, e.g. "ID"
+	Region string
+	// CountryCode is the calling code of the region, e.g. "62"
+	CountryCode string
+	// NationalNumber is the number without the country code and the national prefix,
+	// e.g. "81234567890"
+	NationalNumber string
+	Type           PhoneType
+}
+
+// E164 formats the number in the E.164 format, e.g. "+6281234567890"
+func (p PhoneNumber) E164() string {
+	return "+" + p.CountryCode + p.NationalNumber
+}
+
+type phoneRegion struct {
+	CountryCode         string `json:"country_code"`
+	InternationalPrefix string `json:"international_prefix"`
+	NationalPrefix      string `json:"national_prefix"`
+	Lengths             []int  `json:"lengths"`
+	Mobile              string `json:"mobile"`
+	FixedLine           string `json:"fixed_line"`
+	FixedLineOrMobile   string `json:"fixed_line_or_mobile"`
+
+	internationalPrefix *regexp.Regexp
+	types               []phoneTypePattern
+}
+
+type phoneTypePattern struct {
+	phoneType PhoneType
+	pattern   *regexp.Regexp
+}
+
+// phone_metadata.json contains the numbering plan of each supported region: the country code,
+// the prefixes used to dial out of the region and to dial a national number, the lengths of
+// the national numbers and a pattern for each type of line
+//
+//go:embed phone_metadata.json
+var phoneMetadataFile []byte
+
+var phoneRegions, phoneCountryCodes = mustLoadPhoneMetadata(phoneMetadataFile)
+
+func mustLoadPhoneMetadata(file []byte) (map[string]*phoneRegion, map[string]string) {
+	regions := map[string]*phoneRegion{}
+	if err := json.Unmarshal(file, &regions); err != nil {
+		panic(err)
+	}
+	countryCodes := make(map[string]string, len(regions))
+	for name, region := range regions {
+		region.internationalPrefix = regexp.MustCompile("^(?:" + region.InternationalPrefix + ")")
+		for _, typePattern := range []struct {
+			phoneType PhoneType
+			pattern   string
+		}{
+			{PhoneMobile, region.Mobile},
+			{PhoneFixedLine, region.FixedLine},
+			{PhoneFixedLineOrMobile, region.FixedLineOrMobile},
+		} {
+			if typePattern.pattern != "" {
+				pattern := regexp.MustCompile("^(?:" + typePattern.pattern + ")$")
+				region.types = append(region.types, phoneTypePattern{typePattern.phoneType, pattern})
+			}
+		}
+		countryCodes[region.CountryCode] = name
+	}
+	return regions, countryCodes
+}
+
+// PhoneRegions returns the regions supported by ParsePhone
+func PhoneRegions() []string {
+	regions := make([]string, 0, len(phoneRegions))
+	for region := range phoneRegions {
+		regions = append(regions, region)
+	}
+	return regions
+}
+
+// ParsePhone parses a phone number in the international format, e.g. "+62 812-3456-7890",
+// or in the national format of defaultRegion, e.g. "0812-3456-7890" with "ID"
+//
+// Spaces, dashes, dots, slashes and parentheses are ignored. defaultRegion may be empty
+// to only accept international numbers. The supported regions are ID, MY, SG and US
+func ParsePhone(number string, defaultRegion string) (PhoneNumber, error) {
+	international := strings.HasPrefix(number, "+")
+	digits, ok := phoneDigits(strings.TrimPrefix(number, "+"))
+	if !ok {
+		return PhoneNumber{}, ErrPhoneNumber
+	}
+	region, hasRegion := phoneRegions[strings.ToUpper(defaultRegion)]
+	if !international {
+		if !hasRegion {
+			return PhoneNumber{}, ErrPhoneRegion
+		}
+		if prefix := region.internationalPrefix.FindString(digits); prefix != "" {
+			digits = digits[len(prefix):]
+			international = true
+		}
+	}
+	if !international {
+		national := strings.TrimPrefix(digits, region.NationalPrefix)
+		return parseNationalNumber(strings.ToUpper(defaultRegion), region, national)
+	}
+	for i := 1; i <= 3 && i < len(digits); i++ {
+		if name, ok := phoneCountryCodes[digits[:i]]; ok {
+			return parseNationalNumber(name, phoneRegions[name], digits[i:])
+		}
+	}
+	return PhoneNumber{}, ErrPhoneRegion
+}
+
+func parseNationalNumber(name string, region *phoneRegion, national string) (PhoneNumber, error) {
+	validLength := false
+	for _, length := range region.Lengths {
+		if len(national) == length {
+			validLength = true
+			break
+		}
+	}
+	if !validLength {
+		return PhoneNumber{}, ErrPhoneLength
+	}
+	for _, typePattern := range region.types {
+		if typePattern.pattern.MatchString(national) {
+			return PhoneNumber{
+				Region:         name,
+				CountryCode:    region.CountryCode,
+				NationalNumber: national,
+				Type:           typePattern.phoneType,
+			}, nil
+		}
+	}
+	return PhoneNumber{}, ErrPhoneNumber
+}
+
+// phoneDigits removes the formatting characters of number, and reports false
+// if it has other characters than digits
+func phoneDigits(number string) (string, bool) {
+	var digits strings.Builder
+	digits.Grow(len(number))
+	for i := 0; i < len(number); i++ {
+		switch c := number[i]; {
+		case c >= '0' && c <= '9':
+			digits.WriteByte(c)
+		case strings.IndexByte(" -./()", c) >= 0:
+		default:
+			return "", false
+		}
+	}
+	return digits.String(), digits.Len() > 0
+}
+
+// Phone checks if the data is a phone number that follows the numbering plan of its region,
+// see ParsePhone. If types are given, the number must be one of them, a PhoneFixedLineOrMobile
+// number is both a fixed line and a mobile number
+//
+// Invalid numbers have the tag phone, with two parameters: region (string), the default
+// region, and reason (string), one of "region", "length" or "number".
+// Numbers of another type have the tag phone_type, with one parameter: types ([]string)
+func Phone(number string, defaultRegion string, types ...PhoneType) Validator {
+	return func(field string) *FieldError {
+		parsed, err := ParsePhone(number, defaultRegion)
+		if err != nil {
+			msg := fmt.Sprintf("%s is not a valid phone number", field)
+			fieldErr := NewFieldError(field, msg, "phone", number)
+			fieldErr.SetParam("region", defaultRegion)
+			switch err {
+			case ErrPhoneRegion:
+				fieldErr.SetParam("reason", "region")
+			case ErrPhoneLength:
+				fieldErr.SetParam("reason", "length")
+			default:
+				fieldErr.SetParam("reason", "number")
+			}
+			return fieldErr
+		}
+		if len(types) == 0 {
+			return nil
+		}
+		for _, phoneType := range types {
+			if parsed.Type.matches(phoneType) {
+				return nil
+			}
+		}
+		names := make([]string, len(types))
+		for i, phoneType := range types {
+			names[i] = phoneType.String()
+		}
+		msg := fmt.Sprintf("%s must be a %s phone number", field, strings.Join(names, " or "))
+		fieldErr := NewFieldError(field, msg, "phone_type", number)
+		fieldErr.SetParam("types", names)
+		return fieldErr
+	}
+}
+
+// NormalizePhone formats number in the E.164 format, e.g. "0812-3456-7890" with "ID"
+// into "+6281234567890". It reports false if the number is not valid
+func NormalizePhone(number string, defaultRegion string) (string, bool) {
+	parsed, err := ParsePhone(number, defaultRegion)
+	if err != nil {
+		return number, false
+	}
+	return parsed.E164(), true
+}
+
+// Phone checks if the data is a phone number that follows the numbering plan of its region
+//
+// Has two parameters: defaultRegion (string), for numbers in the national format, and
+// types (...PhoneType), the allowed types of line
+func (v *Builder) Phone(defaultRegion string, types ...PhoneType) *Builder {
+	if v.hasError() {
+		return v
+	}
+	value, ok := v.getString()
+	if ok {
+		v.validation.Add(v.field, Phone(value, defaultRegion, types...))
+	}
+	return v
+}
+
+// NormalizePhone formats the phone number in the E.164 format, numbers in the national
+// format are parsed using defaultRegion. Invalid numbers are left untouched
+func (v *Builder) NormalizePhone(defaultRegion string) *Builder {
+	return v.Transform(func(number string) string {
+		normalized, _ := NormalizePhone(number, defaultRegion)
+		return normalized
+	})
+}
@@ -0,0 +1,140 @@
+package validation
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestParsePhone(t *testing.T) {
+	testCases := []struct {
+		number    string
+		region    string
+		e164      string
+		phoneType PhoneType
+	}{
+		{"+62 812-3456-7890", "", "+6281234567890", PhoneMobile},
+		{"0812 3456 7890", "ID", "+6281234567890", PhoneMobile},
+		{"812-3456-7890", "id", "+6281234567890", PhoneMobile},
+		{"001 62 812 3456 7890", "ID", "+6281234567890", PhoneMobile},
+		{"(021) 123-4567", "ID", "+62211234567", PhoneFixedLine},
+		{"+60 12-345 6789", "", "+60123456789", PhoneMobile},
+		{"011-2345 6789", "MY", "+601123456789", PhoneMobile},
+		{"03-2345 6789", "MY", "+60323456789", PhoneFixedLine},
+		{"+65 9123 4567", "", "+6591234567", PhoneMobile},
+		{"6123 4567", "SG", "+6561234567", PhoneFixedLine},
+		{"+1 (415) 555-2671", "", "+14155552671", PhoneFixedLineOrMobile},
+		{"415.555.2671", "US", "+14155552671", PhoneFixedLineOrMobile},
+		{"1-415-555-2671", "US", "+14155552671", PhoneFixedLineOrMobile},
+		{"011 65 9123 4567", "US", "+6591234567", PhoneMobile},
+	}
+	for _, testCase := range testCases {
+		parsed, err := ParsePhone(testCase.number, testCase.region)
+		if err != nil {
+			t.Fatalf(`value %q is invalid, it should be valid: %v`, testCase.number, err)
+		}
+		if parsed.E164() != testCase.e164 || parsed.Type != testCase.phoneType {
+			t.Fatalf(`value %q should be %s (%s), got %s (%s)`, testCase.number, testCase.e164, testCase.phoneType, parsed.E164(), parsed.Type)
+		}
+	}
+}
+
+func TestParsePhoneErrors(t *testing.T) {
+	testCases := []struct {
+		number string
+		region string
+		err    error
+	}{
+		{"+0", "", ErrPhoneRegion},
+		{"01", "ID", ErrPhoneLength},
+		{"0812 3456 7890", "", ErrPhoneRegion},
+		{"0812 3456 7890", "XX", ErrPhoneRegion},
+		{"+44 20 7946 0958", "", ErrPhoneRegion},
+		{"+62 0812 3456 7890", "", ErrPhoneNumber},
+		{"+62 112 3456 7890", "", ErrPhoneNumber},
+		{"+65 1234 5678", "", ErrPhoneNumber},
+		{"+65 9123 456", "", ErrPhoneLength},
+		{"+1 (115) 555-2671", "", ErrPhoneNumber},
+		{"0812-3456-789O", "ID", ErrPhoneNumber},
+		{"+", "", ErrPhoneNumber},
+	}
+	for _, testCase := range testCases {
+		if _, err := ParsePhone(testCase.number, testCase.region); err != testCase.err {
+			t.Fatalf(`value %q should fail with %v, got %v`, testCase.number, testCase.err, err)
+		}
+	}
+}
+
+func TestPhone(t *testing.T) {
+	testCases := []struct {
+		validator Validator
+		tag       string
+	}{
+		{Phone("0812 3456 7890", "ID"), ""},
+		{Phone("0812 3456 7890", "ID", PhoneMobile), ""},
+		{Phone("(021) 123-4567", "ID", PhoneMobile), "phone_type"},
+		{Phone("(021) 123-4567", "ID", PhoneFixedLine, PhoneMobile), ""},
+		{Phone("+1 415 555 2671", "", PhoneMobile), ""},
+		{Phone("+0", ""), "phone"},
+		{Phone("01", "ID"), "phone"},
+	}
+	for i, testCase := range testCases {
+		err := testCase.validator("phone")
+		if testCase.tag == "" && err != nil {
+			t.Fatalf(`case %d is invalid, it should be valid: %v`, i, err)
+		}
+		if testCase.tag != "" && (err == nil || err.Tag() != testCase.tag) {
+			t.Fatalf(`case %d should be invalid with %s, got %v`, i, testCase.tag, err)
+		}
+	}
+
+	err := Phone("01", "ID")("phone")
+	if err.Param("reason") != "length" || err.Param("region") != "ID" {
+		t.Fatalf("expected the length reason and the ID region, got %v", err.Params())
+	}
+	err = Phone("(021) 123-4567", "ID", PhoneMobile)("phone")
+	if types := err.Param("types").([]string); len(types) != 1 || types[0] != "mobile" {
+		t.Fatalf("expected the mobile type, got %v", err.Params())
+	}
+}
+
+func TestPhoneBuilder(t *testing.T) {
+	v := New()
+	var phone string
+	v.Builder("phone", "0812-3456-7890").Phone("ID", PhoneMobile).NormalizePhone("ID").IntoString(&phone)
+	v.Builder("rules", "0812-3456-7890").Rules("normalize_phone:ID|phone:,mobile")
+	if err := v.Error(); err != nil {
+		t.Fatal("Expected error to be nil, got: ", err)
+	}
+	if phone != "+6281234567890" {
+		t.Fatalf(`expected E.164 phone, got %q`, phone)
+	}
+
+	v = New()
+	v.Builder("phone", "+0").NormalizePhone("").Phone("")
+	if err := v.Error(); err == nil || err.(Error).Errors()["phone"].Value() != "+0" {
+		t.Fatalf("expected the invalid number to be left untouched, got %v", err)
+	}
+
+	invalidRules := []string{"phone:XX", "phone:ID,landline", "normalize_phone:ID,US"}
+	for _, rules := range invalidRules {
+		if _, err := CompileRuleSet(rules); err == nil {
+			t.Fatalf(`rule %q is valid, it should be invalid`, rules)
+		}
+	}
+}
+
+func TestPhoneRegions(t *testing.T) {
+	regions := PhoneRegions()
+	sort.Strings(regions)
+	if len(regions) != 4 || regions[0] != "ID" || regions[3] != "US" {
+		t.Fatalf("expected ID, MY, SG and US, got %v", regions)
+	}
+	for _, name := range []string{"fixed_line", "mobile", "fixed_line_or_mobile"} {
+		if phoneType, err := ParsePhoneType(name); err != nil || phoneType.String() != name {
+			t.Fatalf("expected %s, got %v (%v)", name, phoneType, err)
+		}
+	}
+	if _, err := ParsePhoneType("unknown"); err == nil {
+		t.Fatal("Expected error to be not nil")
+	}
+}
@@ -0,0 +1,199 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// publicURLTimeout bounds the host name resolution of Builder.IsPublicURL
+const publicURLTimeout = 5 * time.Second
+
+// Resolver resolves host names into IP addresses, it is implemented by *net.Resolver
+type Resolver interface {
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+// Reasons of the "reason" param of is_public_url
+const (
+	PublicURLInvalid      = "invalid_url"
+	PublicURLScheme       = "scheme"
+	PublicURLUserinfo     = "userinfo"
+	PublicURLInvalidHost  = "invalid_host"
+	PublicURLUnresolvable = "unresolvable"
+	PublicURLMetadata     = "metadata"
+	PublicURLLoopback     = "loopback"
+	PublicURLPrivate      = "private"
+	PublicURLLinkLocal    = "link_local"
+	PublicURLMulticast    = "multicast"
+	PublicURLReserved     = "reserved"
+)
+
+// metadataHosts are the host names of cloud metadata services
+var metadataHosts = []string{
+	"metadata",
+	"metadata.google.internal",
+	"metadata.goog",
+	"instance-data",
+}
+
+// metadataAddrs are the addresses of cloud metadata services
+var metadataAddrs = []netip.Addr{
+	netip.MustParseAddr("169.254.169.254"), // AWS, GCP, Azure, DigitalOcean, ...
+	netip.MustParseAddr("169.254.170.2"),   // AWS ECS task metadata
+	netip.MustParseAddr("100.100.100.200"), // Alibaba Cloud
+	netip.MustParseAddr("fd00:ec2::254"),   // AWS IPv6
+}
+
+// reservedPrefixes are the special purpose ranges that are not covered by the netip.Addr methods
+var reservedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),       // this network
+	netip.MustParsePrefix("100.64.0.0/10"),   // carrier grade NAT
+	netip.MustParsePrefix("192.0.0.0/24"),    // IETF protocol assignments
+	netip.MustParsePrefix("192.0.2.0/24"),    // documentation
+	netip.MustParsePrefix("198.18.0.0/15"),   // benchmarking
+	netip.MustParsePrefix("198.51.100.0/24"), // documentation
+	netip.MustParsePrefix("203.0.113.0/24"),  // documentation
+	netip.MustParsePrefix("240.0.0.0/4"),     // reserved and broadcast
+	netip.MustParsePrefix("::/96"),           // IPv4-compatible, deprecated, can embed any IPv4 address
+	netip.MustParsePrefix("64:ff9b::/96"),    // NAT64, can embed any IPv4 address
+	netip.MustParsePrefix("64:ff9b:1::/48"),  // local NAT64
+	netip.MustParsePrefix("100::/64"),        // discard
+	netip.MustParsePrefix("2001::/32"),       // Teredo, can embed any IPv4 address
+	netip.MustParsePrefix("2001:db8::/32"),   // documentation
+	netip.MustParsePrefix("2002::/16"),       // 6to4, can embed any IPv4 address
+}
+
+// IsPublicURL checks if the data is an http or https URL that only points to public addresses,
+// so it is safe for the server to request it, e.g. a webhook
+//
+// The URL must not have userinfo. Host names are resolved with resolver, or net.DefaultResolver
+// if it is nil, and every address they resolve to must be public. Loopback, private, link local,
+// multicast, reserved and cloud metadata addresses are rejected.
+// The host name may resolve to another address when it is requested, so the request should
+// be made to the addresses that were checked.
+//
+// Has one parameter: reason (string), one of the PublicURL constants
+func IsPublicURL(ctx context.Context, value string, resolver Resolver) Validator {
+	return func(field string) *FieldError {
+		if reason := checkPublicURL(ctx, value, resolver); reason != "" {
+			msg := fmt.Sprintf("%s is not a public URL", field)
+			err := NewFieldError(field, msg, "is_public_url", value)
+			err.SetParam("reason", reason)
+			return err
+		}
+		return nil
+	}
+}
+
+// checkPublicURL returns the reason why value is not a public URL, or an empty string if it is
+func checkPublicURL(ctx context.Context, value string, resolver Resolver) string {
+	if !isURL(value, urlOptions{requireHost: true}) {
+		return PublicURLInvalid
+	}
+	u, err := url.Parse(value)
+	if err != nil {
+		return PublicURLInvalid
+	}
+	if scheme := strings.ToLower(u.Scheme); scheme != "http" && scheme != "https" {
+		return PublicURLScheme
+	}
+	if u.User != nil {
+		return PublicURLUserinfo
+	}
+	host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+	if addr, err := netip.ParseAddr(host); err == nil {
+		return checkPublicAddr(addr)
+	}
+	if !isPublicHostname(host) {
+		return PublicURLInvalidHost
+	}
+	for _, metadataHost := range metadataHosts {
+		if host == metadataHost {
+			return PublicURLMetadata
+		}
+	}
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+	addrs, err := resolver.LookupIPAddr(ctx, host)
+	if err != nil || len(addrs) == 0 {
+		return PublicURLUnresolvable
+	}
+	for _, ipAddr := range addrs {
+		addr, ok := netip.AddrFromSlice(ipAddr.IP)
+		if !ok {
+			return PublicURLUnresolvable
+		}
+		if reason := checkPublicAddr(addr); reason != "" {
+			return reason
+		}
+	}
+	return ""
+}
+
+// isPublicHostname checks if host is a host name with a top level domain that doesn't start
+// with a digit, so numeric hosts like "2130706433" or "0x7f.1", which some resolvers
+// parse as IPv4 addresses, are rejected
+func isPublicHostname(host string) bool {
+	if !isHostname(host) {
+		return false
+	}
+	tld := host[strings.LastIndexByte(host, '.')+1:]
+	return tld[0] < '0' || tld[0] > '9'
+}
+
+// checkPublicAddr returns the reason why addr is not public, or an empty string if it is
+func checkPublicAddr(addr netip.Addr) string {
+	addr = addr.Unmap().WithZone("")
+	for _, metadataAddr := range metadataAddrs {
+		if addr == metadataAddr {
+			return PublicURLMetadata
+		}
+	}
+	switch {
+	case addr.IsLoopback():
+		return PublicURLLoopback
+	case addr.IsPrivate():
+		return PublicURLPrivate
+	case addr.IsLinkLocalUnicast():
+		return PublicURLLinkLocal
+	case addr.IsMulticast():
+		return PublicURLMulticast
+	case addr.IsUnspecified(), !addr.IsGlobalUnicast():
+		return PublicURLReserved
+	}
+	for _, prefix := range reservedPrefixes {
+		if prefix.Contains(addr) {
+			return PublicURLReserved
+		}
+	}
+	return ""
+}
+
+// IsPublicURL checks if the data is an http or https URL that only points to public addresses
+//
+// Host names are resolved with the resolver of the validation, see Validation.SetResolver.
+// The resolution gives up after 5 seconds, use IsPublicURLContext to control it
+func (v *Builder) IsPublicURL() *Builder {
+	ctx, cancel := context.WithTimeout(context.Background(), publicURLTimeout)
+	defer cancel()
+	return v.IsPublicURLContext(ctx)
+}
+
+// IsPublicURLContext is like IsPublicURL but resolves host names with ctx,
+// so the resolution can be canceled or given a deadline
+func (v *Builder) IsPublicURLContext(ctx context.Context) *Builder {
+	if v.hasError() {
+		return v
+	}
+	value, ok := v.getString()
+	if ok {
+		v.validation.Add(v.field, IsPublicURL(ctx, value, v.validation.resolver))
+	}
+	return v
+}
@@ -0,0 +1,7 @@
+//go:build race
+
+package validation
+
+// raceEnabled reports whether the tests run with the race detector,
+// which allocates on its own and breaks the allocation tests
+const raceEnabled = true
@@ -0,0 +1,227 @@
+package validation
+
+import (
+	"fmt"
+	"time"
+)
+
+// InPast checks if the date is before the current time of clock, or of the system clock if
+// it is nil
+func InPast(date time.Time, clock Clock) Validator {
+	return func(field string) *FieldError {
+		current := now(clock)
+		if !date.In(current.Location()).Before(current) {
+			msg := fmt.Sprintf("%s must be in the past", field)
+			return NewFieldError(field, msg, "in_past", date)
+		}
+		return nil
+	}
+}
+
+// InFuture checks if the date is after the current time of clock, or of the system clock if
+// it is nil
+func InFuture(date time.Time, clock Clock) Validator {
+	return func(field string) *FieldError {
+		current := now(clock)
+		if !date.In(current.Location()).After(current) {
+			msg := fmt.Sprintf("%s must be in the future", field)
+			return NewFieldError(field, msg, "in_future", date)
+		}
+		return nil
+	}
+}
+
+// Today checks if the date is on the current day of clock, in the timezone of clock
+func Today(date time.Time, clock Clock) Validator {
+	return func(field string) *FieldError {
+		current := now(clock)
+		year, month, day := date.In(current.Location()).Date()
+		currentYear, currentMonth, currentDay := current.Date()
+		if year != currentYear || month != currentMonth || day != currentDay {
+			msg := fmt.Sprintf("%s must be today", field)
+			return NewFieldError(field, msg, "today", date)
+		}
+		return nil
+	}
+}
+
+// WithinLast checks if the date is between d before the current time of clock and
+// the current time
+//
+// Has one parameter: duration (time.Duration)
+func WithinLast(date time.Time, d time.Duration, clock Clock) Validator {
+	return func(field string) *FieldError {
+		current := now(clock)
+		instant := date.In(current.Location())
+		if instant.Before(current.Add(-d)) || instant.After(current) {
+			msg := fmt.Sprintf("%s must be within the last %s", field, d)
+			err := NewFieldError(field, msg, "within_last", date)
+			err.SetParam("duration", d)
+			return err
+		}
+		return nil
+	}
+}
+
+// WithinNext checks if the date is between the current time of clock and d after it
+//
+// Has one parameter: duration (time.Duration)
+func WithinNext(date time.Time, d time.Duration, clock Clock) Validator {
+	return func(field string) *FieldError {
+		current := now(clock)
+		instant := date.In(current.Location())
+		if instant.Before(current) || instant.After(current.Add(d)) {
+			msg := fmt.Sprintf("%s must be within the next %s", field, d)
+			err := NewFieldError(field, msg, "within_next", date)
+			err.SetParam("duration", d)
+			return err
+		}
+		return nil
+	}
+}
+
+// Age returns the age in full years of someone born on birthdate at the current time of clock.
+// The calendar date of birthdate is read in its own location, since a birthday is a date and
+// not an instant, and compared with the current date in the timezone of clock.
+// Someone born on February 29 gets one year older on March 1 in common years
+func Age(birthdate time.Time, clock Clock) int {
+	current := now(clock)
+	year, month, day := birthdate.Date()
+	currentYear, currentMonth, currentDay := current.Date()
+	age := currentYear - year
+	if currentMonth < month || (currentMonth == month && currentDay < day) {
+		age--
+	}
+	return age
+}
+
+// MinAge checks if someone born on birthdate is at least years old, see Age
+//
+// Has two parameters: min_age (int), and age (int), the age of the person
+func MinAge(birthdate time.Time, years int, clock Clock) Validator {
+	return func(field string) *FieldError {
+		if age := Age(birthdate, clock); age < years {
+			msg := fmt.Sprintf("%s must be at least %d years old", field, years)
+			err := NewFieldError(field, msg, "min_age", birthdate)
+			err.SetParam("min_age", years)
+			err.SetParam("age", age)
+			return err
+		}
+		return nil
+	}
+}
+
+// MaxAge checks if someone born on birthdate is at most years old, see Age
+//
+// Has two parameters: max_age (int), and age (int), the age of the person
+func MaxAge(birthdate time.Time, years int, clock Clock) Validator {
+	return func(field string) *FieldError {
+		if age := Age(birthdate, clock); age > years {
+			msg := fmt.Sprintf("%s must be at most %d years old", field, years)
+			err := NewFieldError(field, msg, "max_age", birthdate)
+			err.SetParam("max_age", years)
+			err.SetParam("age", age)
+			return err
+		}
+		return nil
+	}
+}
+
+// InPast checks if the date is before the current time of the clock of the validation
+func (v *Builder) InPast() *Builder {
+	if v.hasError() {
+		return v
+	}
+	value, ok := v.getTimeIn(v.clockLocation())
+	if ok {
+		v.validation.Add(v.field, InPast(value, v.validation.clock))
+	}
+	return v
+}
+
+// InFuture checks if the date is after the current time of the clock of the validation
+func (v *Builder) InFuture() *Builder {
+	if v.hasError() {
+		return v
+	}
+	value, ok := v.getTimeIn(v.clockLocation())
+	if ok {
+		v.validation.Add(v.field, InFuture(value, v.validation.clock))
+	}
+	return v
+}
+
+// Today checks if the date is on the current day of the clock of the validation
+func (v *Builder) Today() *Builder {
+	if v.hasError() {
+		return v
+	}
+	value, ok := v.getTimeIn(v.clockLocation())
+	if ok {
+		v.validation.Add(v.field, Today(value, v.validation.clock))
+	}
+	return v
+}
+
+// WithinLast checks if the date is between d before the current time and the current time
+//
+// Has one parameter: d (time.Duration)
+func (v *Builder) WithinLast(d time.Duration) *Builder {
+	if v.hasError() {
+		return v
+	}
+	value, ok := v.getTimeIn(v.clockLocation())
+	if ok {
+		v.validation.Add(v.field, WithinLast(value, d, v.validation.clock))
+	}
+	return v
+}
+
+// WithinNext checks if the date is between the current time and d after it
+//
+// Has one parameter: d (time.Duration)
+func (v *Builder) WithinNext(d time.Duration) *Builder {
+	if v.hasError() {
+		return v
+	}
+	value, ok := v.getTimeIn(v.clockLocation())
+	if ok {
+		v.validation.Add(v.field, WithinNext(value, d, v.validation.clock))
+	}
+	return v
+}
+
+// MinAge checks if someone born on the date is at least years old
+//
+// Has one parameter: years (int)
+func (v *Builder) MinAge(years int) *Builder {
+	if v.hasError() {
+		return v
+	}
+	value, ok := v.getTimeIn(v.clockLocation())
+	if ok {
+		v.validation.Add(v.field, MinAge(value, years, v.validation.clock))
+	}
+	return v
+}
+
+// MaxAge checks if someone born on the date is at most years old
+//
+// Has one parameter: years (int)
+func (v *Builder) MaxAge(years int) *Builder {
+	if v.hasError() {
+		return v
+	}
+	value, ok := v.getTimeIn(v.clockLocation())
+	if ok {
+		v.validation.Add(v.field, MaxAge(value, years, v.validation.clock))
+	}
+	return v
+}
+
+// clockLocation returns the timezone of the clock of the validation, used to parse
+// the dates without a timezone of the relative date builders, so "2006-01-02" is
+// the start of that day for the user
+func (v *Builder) clockLocation() *time.Location {
+	return now(v.validation.clock).Location()
+}
@@ -0,0 +1,126 @@
+package validation
+
+import (
+	"testing"
+	"time"
+)
+
+var (
+	wib      = time.FixedZone("WIB", 7*60*60)
+	testNow  = time.Date(2026, 10, 18, 0, 30, 0, 0, wib)
+	testWIB  = FixedClock(testNow)
+	testDate = func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, wib)
+	}
+)
+
+func TestRelativeDates(t *testing.T) {
+	testCases := []struct {
+		validator Validator
+		tag       string
+	}{
+		{InPast(testNow.Add(-time.Second), testWIB), ""},
+		{InPast(testNow, testWIB), "in_past"},
+		{InPast(testDate(2026, 10, 18), testWIB), ""},
+		{InPast(testDate(2026, 10, 19), testWIB), "in_past"},
+		{InFuture(testNow.Add(time.Second), testWIB), ""},
+		{InFuture(testNow, testWIB), "in_future"},
+		{InFuture(testDate(2026, 10, 19), testWIB), ""},
+		// an instant at midnight UTC is 07:00 in WIB, not the start of the day
+		{InFuture(time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC), testWIB), ""},
+		{InPast(time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC), testWIB), ""},
+		{Today(testDate(2026, 10, 18), testWIB), ""},
+		// 2026-10-17 18:00 UTC is 2026-10-18 01:00 in WIB
+		{Today(time.Date(2026, 10, 17, 18, 0, 0, 0, time.UTC), testWIB), ""},
+		{Today(time.Date(2026, 10, 17, 16, 0, 0, 0, time.UTC), testWIB), "today"},
+		{Today(testDate(2026, 10, 17), testWIB), "today"},
+		{WithinLast(testNow.Add(-time.Hour), 2*time.Hour, testWIB), ""},
+		{WithinLast(testNow.Add(-3*time.Hour), 2*time.Hour, testWIB), "within_last"},
+		{WithinLast(testNow.Add(time.Minute), 2*time.Hour, testWIB), "within_last"},
+		{WithinNext(testNow.Add(time.Hour), 2*time.Hour, testWIB), ""},
+		{WithinNext(testNow.Add(3*time.Hour), 2*time.Hour, testWIB), "within_next"},
+		{WithinNext(testNow.Add(-time.Minute), 2*time.Hour, testWIB), "within_next"},
+		{MinAge(testDate(2008, 10, 18), 18, testWIB), ""},
+		{MinAge(testDate(2008, 10, 19), 18, testWIB), "min_age"},
+		{MaxAge(testDate(1960, 10, 19), 65, testWIB), ""},
+		{MaxAge(testDate(1960, 10, 18), 65, testWIB), "max_age"},
+	}
+	for i, testCase := range testCases {
+		err := testCase.validator("date")
+		if testCase.tag == "" && err != nil {
+			t.Fatalf(`case %d is invalid, it should be valid: %v`, i, err)
+		}
+		if testCase.tag != "" && (err == nil || err.Tag() != testCase.tag) {
+			t.Fatalf(`case %d should be invalid with %s, got %v`, i, testCase.tag, err)
+		}
+	}
+}
+
+func TestAge(t *testing.T) {
+	losAngeles, loadErr := time.LoadLocation("America/Los_Angeles")
+	if loadErr != nil {
+		t.Fatal(loadErr)
+	}
+	testCases := []struct {
+		birthdate time.Time
+		now       time.Time
+		age       int
+	}{
+		{testDate(2000, 10, 18), testNow, 26},
+		{testDate(2000, 10, 19), testNow, 25},
+		{testDate(2000, 2, 29), time.Date(2026, 2, 28, 12, 0, 0, 0, wib), 25},
+		{testDate(2000, 2, 29), time.Date(2026, 3, 1, 0, 0, 0, 0, wib), 26},
+		{testDate(2000, 2, 29), time.Date(2028, 2, 29, 0, 0, 0, 0, wib), 28},
+		// born at 23:00 UTC on October 18, which is October 19 in WIB, the birthday is still October 18
+		{time.Date(2000, 10, 18, 23, 0, 0, 0, time.UTC), time.Date(2026, 10, 18, 6, 0, 0, 0, wib), 26},
+		// a date parsed as UTC midnight is still October 18 for a clock behind UTC
+		{time.Date(2008, 10, 18, 0, 0, 0, 0, time.UTC), time.Date(2026, 10, 17, 10, 0, 0, 0, losAngeles), 17},
+		{time.Date(2008, 10, 18, 0, 0, 0, 0, time.UTC), time.Date(2026, 10, 18, 0, 30, 0, 0, losAngeles), 18},
+	}
+	for _, testCase := range testCases {
+		if age := Age(testCase.birthdate, FixedClock(testCase.now)); age != testCase.age {
+			t.Fatalf(`birthdate %v should be %d years old on %v, got %d`, testCase.birthdate, testCase.age, testCase.now, age)
+		}
+	}
+
+	err := MinAge(testDate(2010, 1, 1), 18, testWIB)("birthdate")
+	if err.Param("min_age") != 18 || err.Param("age") != 16 {
+		t.Fatalf("expected the min age and the age params, got %v", err.Params())
+	}
+	if err.Message() != "birthdate must be at least 18 years old" {
+		t.Fatalf("unexpected message %q", err.Message())
+	}
+}
+
+func TestRelativeDatesBuilder(t *testing.T) {
+	v := New()
+	v.SetClock(testWIB)
+	v.Builder("birthdate", "2008-10-18").MinAge(18).MaxAge(120).InPast()
+	v.Builder("appointment", "2026-10-20T09:00:00+07:00").InFuture().WithinNext(7 * 24 * time.Hour)
+	v.Builder("login", testNow.Add(-time.Minute)).WithinLast(time.Hour).Today()
+	v.Builder("rules", "2008-10-18").Rules("in_past|min_age:18|max_age:120")
+	v.Builder("rules_today", "2026-10-18").Rules("today|within_last:1h")
+	v.Builder("rules_future", "2026-10-19").Rules("in_future|within_next:24h")
+	var missing *time.Time
+	v.Builder("missing", missing).InPast().MinAge(18)
+	if err := v.Error(); err != nil {
+		t.Fatal("Expected error to be nil, got: ", err)
+	}
+
+	v = New()
+	v.SetClock(testWIB)
+	v.Builder("birthdate", "2008-10-19").MinAge(18)
+	v.Builder("appointment", "2026-10-17").InFuture()
+	v.Builder("instant", "2026-10-17T16:00:00Z").Today()
+	errs := v.Error().(Error).Errors()
+	if errs["birthdate"].Tag() != "min_age" || errs["appointment"].Tag() != "in_future" || errs["instant"].Tag() != "today" {
+		t.Fatalf("expected min_age, in_future and today, got %v", errs)
+	}
+
+	invalidRules := []string{"within_last", "within_last:1x", "within_next:1h,2h", "min_age:x", "max_age", "today:1"}
+	for _, rules := range invalidRules {
+		if _, err := CompileRuleSet(rules); err == nil {
+			t.Fatalf(`rule %q is valid, it should be invalid`, rules)
+		}
+	}
+}
@@ -0,0 +1,646 @@
+package validation
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Rule is a single rule of a rule string,
+// e.g. "min:0" is parsed into Rule{Name: "min", Args: []string{"0"}}
+type Rule struct {
+	Name string
+	Args []string
+}
+
+// String formats the rule back into its rule string form
+func (r Rule) String() string {
+	if len(r.Args) == 0 {
+		return r.Name
+	}
+	return r.Name + ":" + strings.Join(r.Args, ",")
+}
+
+// RuleFunc applies a compiled rule to a builder
+type RuleFunc func(b *Builder) *Builder
+
+// RuleCompiler compiles the arguments of a rule into a RuleFunc
+type RuleCompiler func(args []string) (RuleFunc, error)
+
+var (
+	rulesMu sync.RWMutex
+	rules   = map[string]RuleCompiler{}
+)
+
+// RegisterRule makes a rule available to rule strings under name
+//
+// Registering a rule with the name of an existing rule replaces it
+func RegisterRule(name string, compiler RuleCompiler) {
+	rulesMu.Lock()
+	defer rulesMu.Unlock()
+	rules[name] = compiler
+}
+
+func lookupRule(name string) (RuleCompiler, bool) {
+	rulesMu.RLock()
+	defer rulesMu.RUnlock()
+	compiler, ok := rules[name]
+	return compiler, ok
+}
+
+// ParseRules parses a rule string such as "required|numeric|min:0"
+//
+// Rules are separated by "|", the name of a rule is separated from its
+// arguments by ":" and the arguments are separated by ",".
+// ParseRules only checks the syntax, use CompileRuleSet to check the rules exist
+func ParseRules(rules string) ([]Rule, error) {
+	parsed := make([]Rule, 0)
+	for _, part := range strings.Split(rules, "|") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, args, hasArgs := strings.Cut(part, ":")
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return nil, fmt.Errorf("validation: missing rule name in %q", part)
+		}
+		rule := Rule{Name: name}
+		if hasArgs {
+			for _, arg := range strings.Split(args, ",") {
+				rule.Args = append(rule.Args, strings.TrimSpace(arg))
+			}
+		}
+		parsed = append(parsed, rule)
+	}
+	return parsed, nil
+}
+
+// RuleSet is a compiled rule string that can be applied to many builders
+type RuleSet struct {
+	rules []Rule
+	funcs []RuleFunc
+}
+
+// CompileRuleSet parses and compiles a rule string such as "required|numeric|min:0"
+func CompileRuleSet(rules string) (*RuleSet, error) {
+	parsed, err := ParseRules(rules)
+	if err != nil {
+		return nil, err
+	}
+	set := &RuleSet{rules: parsed, funcs: make([]RuleFunc, 0, len(parsed))}
+	for _, rule := range parsed {
+		compiler, ok := lookupRule(rule.Name)
+		if !ok {
+			return nil, fmt.Errorf("validation: unknown rule %q", rule.Name)
+		}
+		fn, err := compiler(rule.Args)
+		if err != nil {
+			return nil, fmt.Errorf("validation: rule %q: %w", rule.Name, err)
+		}
+		set.funcs = append(set.funcs, fn)
+	}
+	return set, nil
+}
+
+// MustCompileRuleSet is like CompileRuleSet but panics if the rule string is invalid
+func MustCompileRuleSet(rules string) *RuleSet {
+	set, err := CompileRuleSet(rules)
+	if err != nil {
+		panic(err)
+	}
+	return set
+}
+
+// Rules returns the parsed rules of the rule set
+func (r *RuleSet) Rules() []Rule {
+	return r.rules
+}
+
+// Apply applies every rule of the rule set to b, in order
+//
+// Missing values, nil or a nil pointer, only go through the required rule,
+// every other rule is skipped
+func (r *RuleSet) Apply(b *Builder) *Builder {
+	if !b.isSet() {
+		for i, fn := range r.funcs {
+			if r.rules[i].Name == "required" {
+				fn(b)
+			}
+		}
+		return b
+	}
+	for _, fn := range r.funcs {
+		fn(b)
+	}
+	return b
+}
+
+// Rules applies a rule string such as "required|numeric|min:0" to the builder
+//
+// It panics if the rule string is invalid, use CompileRuleSet to check it beforehand
+func (v *Builder) Rules(rules string) *Builder {
+	return MustCompileRuleSet(rules).Apply(v)
+}
+
+func noArgs(fn RuleFunc) RuleCompiler {
+	return func(args []string) (RuleFunc, error) {
+		if len(args) != 0 {
+			return nil, fmt.Errorf("expects no arguments, got %d", len(args))
+		}
+		return fn, nil
+	}
+}
+
+func checkArgs(args []string, n int) error {
+	if len(args) != n {
+		return fmt.Errorf("expects %d arguments, got %d", n, len(args))
+	}
+	return nil
+}
+
+// lengthModeArg splits the optional length mode, e.g. "runes" in "min_length:3,runes",
+// from the n other arguments of a length rule
+func lengthModeArg(args []string, n int) ([]string, LengthMode, error) {
+	if len(args) != n+1 {
+		return args, 0, nil
+	}
+	mode, err := ParseLengthMode(args[n])
+	if err != nil {
+		return nil, 0, err
+	}
+	return args[:n], mode, nil
+}
+
+// withLengthMode applies fn with the length mode of the builder set to mode, if any
+func withLengthMode(mode LengthMode, fn RuleFunc) RuleFunc {
+	if mode == 0 {
+		return fn
+	}
+	return func(b *Builder) *Builder {
+		prev := b.lengthMode
+		b.lengthMode = mode
+		fn(b)
+		b.lengthMode = prev
+		return b
+	}
+}
+
+func parseIntArgs(args []string, n int) ([]int, error) {
+	if err := checkArgs(args, n); err != nil {
+		return nil, err
+	}
+	values := make([]int, n)
+	for i, arg := range args {
+		value, err := strconv.Atoi(arg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer argument %q", arg)
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+// parseDurationArg parses the single duration argument of a rule, e.g. "24h" or "PT24H"
+func parseDurationArg(args []string) (time.Duration, error) {
+	if err := checkArgs(args, 1); err != nil {
+		return 0, err
+	}
+	d, err := ParseDuration(args[0])
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration argument %q", args[0])
+	}
+	return d, nil
+}
+
+// parseLengthArgs parses the lengths or versions of the is_isbn and is_gtin rules
+func parseLengthArgs(args []string, allowed ...int) ([]int, error) {
+	lengths := make([]int, len(args))
+	for i, arg := range args {
+		length, err := strconv.Atoi(arg)
+		if err != nil || !allowsLength(allowed, length) {
+			return nil, fmt.Errorf("invalid length argument %q", arg)
+		}
+		lengths[i] = length
+	}
+	return lengths, nil
+}
+
+func parseFloatArgs(args []string, n int) ([]float64, error) {
+	if err := checkArgs(args, n); err != nil {
+		return nil, err
+	}
+	values := make([]float64, n)
+	for i, arg := range args {
+		value, err := strconv.ParseFloat(arg, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number argument %q", arg)
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+// parseNumberArgs parses the arguments both as floats and as exact decimals,
+// so that big numbers and json.Number are compared exactly, see ParseDecimal
+func parseNumberArgs(args []string, n int) ([]float64, []*big.Rat, error) {
+	values, err := parseFloatArgs(args, n)
+	if err != nil {
+		return nil, nil, err
+	}
+	decimals := make([]*big.Rat, n)
+	for i, arg := range args {
+		if decimals[i], err = ParseDecimal(arg); err != nil {
+			return nil, nil, fmt.Errorf("invalid number argument %q", arg)
+		}
+	}
+	return values, decimals, nil
+}
+
+func parseDateArgs(args []string, n int) ([]time.Time, error) {
+	if err := checkArgs(args, n); err != nil {
+		return nil, err
+	}
+	values := make([]time.Time, n)
+	for i, arg := range args {
+		value, err := time.Parse(time.RFC3339, arg)
+		if err != nil {
+			value, err = time.Parse("2006-01-02", arg)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid date argument %q", arg)
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+func init() {
+	RegisterRule("required", noArgs((*Builder).Required))
+	RegisterRule("numeric", noArgs((*Builder).Numeric))
+	RegisterRule("is_email", noArgs((*Builder).IsEmail))
+	RegisterRule("is_alphanumeric", noArgs((*Builder).IsAlphanumeric))
+	RegisterRule("is_iso8601", noArgs((*Builder).IsISO8601))
+	RegisterRule("is_iso8601_date", noArgs((*Builder).IsISO8601Date))
+	RegisterRule("is_phone", noArgs((*Builder).IsPhone))
+	RegisterRule("is_uuid", noArgs((*Builder).IsUUID))
+	RegisterRule("is_only_digits", noArgs((*Builder).IsOnlyDigits))
+	RegisterRule("is_ip", noArgs((*Builder).IsIP))
+	RegisterRule("is_ipv4", noArgs((*Builder).IsIPv4))
+	RegisterRule("is_ipv6", noArgs((*Builder).IsIPv6))
+	RegisterRule("is_cidr", noArgs((*Builder).IsCIDR))
+	RegisterRule("is_hostname", noArgs((*Builder).IsHostname))
+	RegisterRule("is_fqdn", noArgs((*Builder).IsFQDN))
+	RegisterRule("is_mac", noArgs((*Builder).IsMAC))
+	RegisterRule("is_port", noArgs((*Builder).IsPort))
+	RegisterRule("is_public_url", noArgs((*Builder).IsPublicURL))
+	RegisterRule("is_nik", noArgs((*Builder).IsNIK))
+	RegisterRule("is_no_kk", noArgs((*Builder).IsNoKK))
+	RegisterRule("is_npwp", noArgs((*Builder).IsNPWP))
+	RegisterRule("is_kode_pos", noArgs((*Builder).IsKodePos))
+	RegisterRule("is_card_expiry", noArgs((*Builder).IsCardExpiry))
+	RegisterRule("is_iban", noArgs((*Builder).IsIBAN))
+	RegisterRule("is_bic", noArgs((*Builder).IsBIC))
+	RegisterRule("is_issn", noArgs((*Builder).IsISSN))
+	RegisterRule("in_past", noArgs((*Builder).InPast))
+	RegisterRule("in_future", noArgs((*Builder).InFuture))
+	RegisterRule("today", noArgs((*Builder).Today))
+	RegisterRule("is_time_of_day", noArgs((*Builder).IsTimeOfDay))
+	RegisterRule("is_business_day", noArgs((*Builder).IsBusinessDay))
+	RegisterRule("not_holiday", noArgs((*Builder).NotHoliday))
+	RegisterRule("positive", noArgs((*Builder).Positive))
+	RegisterRule("negative", noArgs((*Builder).Negative))
+	RegisterRule("non_negative", noArgs((*Builder).NonNegative))
+	RegisterRule("non_zero", noArgs((*Builder).NonZero))
+	RegisterRule("finite", noArgs((*Builder).Finite))
+	RegisterRule("normalize_email", noArgs((*Builder).NormalizeEmail))
+	RegisterRule("trim", noArgs((*Builder).Trim))
+	RegisterRule("lower", noArgs((*Builder).Lower))
+	RegisterRule("upper", noArgs((*Builder).Upper))
+	RegisterRule("collapse_spaces", noArgs((*Builder).CollapseSpaces))
+	RegisterRule("strip_non_digits", noArgs((*Builder).StripNonDigits))
+	RegisterRule("min", func(args []string) (RuleFunc, error) {
+		values, decimals, err := parseNumberArgs(args, 1)
+		if err != nil {
+			return nil, err
+		}
+		return func(b *Builder) *Builder {
+			if b.isDecimal() {
+				return b.MinDecimal(decimals[0])
+			}
+			return b.MinFloat(values[0])
+		}, nil
+	})
+	RegisterRule("max", func(args []string) (RuleFunc, error) {
+		values, decimals, err := parseNumberArgs(args, 1)
+		if err != nil {
+			return nil, err
+		}
+		return func(b *Builder) *Builder {
+			if b.isDecimal() {
+				return b.MaxDecimal(decimals[0])
+			}
+			return b.MaxFloat(values[0])
+		}, nil
+	})
+	RegisterRule("range", func(args []string) (RuleFunc, error) {
+		values, decimals, err := parseNumberArgs(args, 2)
+		if err != nil {
+			return nil, err
+		}
+		return func(b *Builder) *Builder {
+			if b.isDecimal() {
+				return b.RangeDecimal(decimals[0], decimals[1])
+			}
+			return b.RangeFloat(values[0], values[1])
+		}, nil
+	})
+	RegisterRule("multiple_of", func(args []string) (RuleFunc, error) {
+		values, decimals, err := parseNumberArgs(args, 1)
+		if err != nil {
+			return nil, err
+		}
+		return func(b *Builder) *Builder {
+			if b.isDecimal() {
+				return b.MultipleOfDecimal(decimals[0])
+			}
+			return b.MultipleOf(values[0])
+		}, nil
+	})
+	RegisterRule("step", func(args []string) (RuleFunc, error) {
+		// the base is optional and defaults to 0
+		if len(args) == 1 {
+			args = append(args, "0")
+		}
+		values, err := parseFloatArgs(args, 2)
+		if err != nil {
+			return nil, err
+		}
+		return func(b *Builder) *Builder { return b.Step(values[0], values[1]) }, nil
+	})
+	RegisterRule("digits", func(args []string) (RuleFunc, error) {
+		values, err := parseIntArgs(args, 2)
+		if err != nil {
+			return nil, err
+		}
+		if values[1] < 0 || values[0] < values[1] {
+			return nil, fmt.Errorf("invalid precision %d and scale %d", values[0], values[1])
+		}
+		return func(b *Builder) *Builder { return b.Digits(values[0], values[1]) }, nil
+	})
+	RegisterRule("min_length", func(args []string) (RuleFunc, error) {
+		args, mode, err := lengthModeArg(args, 1)
+		if err != nil {
+			return nil, err
+		}
+		values, err := parseIntArgs(args, 1)
+		if err != nil {
+			return nil, err
+		}
+		return withLengthMode(mode, func(b *Builder) *Builder { return b.MinLength(values[0]) }), nil
+	})
+	RegisterRule("max_length", func(args []string) (RuleFunc, error) {
+		args, mode, err := lengthModeArg(args, 1)
+		if err != nil {
+			return nil, err
+		}
+		values, err := parseIntArgs(args, 1)
+		if err != nil {
+			return nil, err
+		}
+		return withLengthMode(mode, func(b *Builder) *Builder { return b.MaxLength(values[0]) }), nil
+	})
+	RegisterRule("length", func(args []string) (RuleFunc, error) {
+		args, mode, err := lengthModeArg(args, 2)
+		if err != nil {
+			return nil, err
+		}
+		values, err := parseIntArgs(args, 2)
+		if err != nil {
+			return nil, err
+		}
+		return withLengthMode(mode, func(b *Builder) *Builder { return b.Length(values[0], values[1]) }), nil
+	})
+	RegisterRule("min_count", func(args []string) (RuleFunc, error) {
+		values, err := parseIntArgs(args, 1)
+		if err != nil {
+			return nil, err
+		}
+		return func(b *Builder) *Builder { return b.MinCount(values[0]) }, nil
+	})
+	RegisterRule("max_count", func(args []string) (RuleFunc, error) {
+		values, err := parseIntArgs(args, 1)
+		if err != nil {
+			return nil, err
+		}
+		return func(b *Builder) *Builder { return b.MaxCount(values[0]) }, nil
+	})
+	RegisterRule("one_of", func(args []string) (RuleFunc, error) {
+		if len(args) == 0 {
+			return nil, fmt.Errorf("expects at least 1 argument")
+		}
+		return func(b *Builder) *Builder { return b.OneOf(args...) }, nil
+	})
+	RegisterRule("min_date", func(args []string) (RuleFunc, error) {
+		values, err := parseDateArgs(args, 1)
+		if err != nil {
+			return nil, err
+		}
+		return func(b *Builder) *Builder { return b.MinDate(values[0]) }, nil
+	})
+	RegisterRule("max_date", func(args []string) (RuleFunc, error) {
+		values, err := parseDateArgs(args, 1)
+		if err != nil {
+			return nil, err
+		}
+		return func(b *Builder) *Builder { return b.MaxDate(values[0]) }, nil
+	})
+	RegisterRule("between_date", func(args []string) (RuleFunc, error) {
+		values, err := parseDateArgs(args, 2)
+		if err != nil {
+			return nil, err
+		}
+		return func(b *Builder) *Builder { return b.BetweenDate(values[0], values[1]) }, nil
+	})
+	RegisterRule("date_format", func(args []string) (RuleFunc, error) {
+		if err := checkArgs(args, 1); err != nil {
+			return nil, err
+		}
+		return func(b *Builder) *Builder { return b.DateFormat(args[0]) }, nil
+	})
+	RegisterRule("within_last", func(args []string) (RuleFunc, error) {
+		d, err := parseDurationArg(args)
+		if err != nil {
+			return nil, err
+		}
+		return func(b *Builder) *Builder { return b.WithinLast(d) }, nil
+	})
+	RegisterRule("within_next", func(args []string) (RuleFunc, error) {
+		d, err := parseDurationArg(args)
+		if err != nil {
+			return nil, err
+		}
+		return func(b *Builder) *Builder { return b.WithinNext(d) }, nil
+	})
+	RegisterRule("min_duration", func(args []string) (RuleFunc, error) {
+		d, err := parseDurationArg(args)
+		if err != nil {
+			return nil, err
+		}
+		return func(b *Builder) *Builder { return b.MinDuration(d) }, nil
+	})
+	RegisterRule("max_duration", func(args []string) (RuleFunc, error) {
+		d, err := parseDurationArg(args)
+		if err != nil {
+			return nil, err
+		}
+		return func(b *Builder) *Builder { return b.MaxDuration(d) }, nil
+	})
+	RegisterRule("time_of_day_between", func(args []string) (RuleFunc, error) {
+		if err := checkArgs(args, 2); err != nil {
+			return nil, err
+		}
+		open, err := ParseTimeOfDay(args[0])
+		if err != nil {
+			return nil, err
+		}
+		close, err := ParseTimeOfDay(args[1])
+		if err != nil {
+			return nil, err
+		}
+		return func(b *Builder) *Builder { return b.TimeOfDayBetween(open, close) }, nil
+	})
+	RegisterRule("min_business_days", func(args []string) (RuleFunc, error) {
+		values, err := parseIntArgs(args, 1)
+		if err != nil {
+			return nil, err
+		}
+		return func(b *Builder) *Builder {
+			return b.AtLeastBusinessDaysAfter(now(b.validation.clock), values[0])
+		}, nil
+	})
+	RegisterRule("min_age", func(args []string) (RuleFunc, error) {
+		values, err := parseIntArgs(args, 1)
+		if err != nil {
+			return nil, err
+		}
+		return func(b *Builder) *Builder { return b.MinAge(values[0]) }, nil
+	})
+	RegisterRule("max_age", func(args []string) (RuleFunc, error) {
+		values, err := parseIntArgs(args, 1)
+		if err != nil {
+			return nil, err
+		}
+		return func(b *Builder) *Builder { return b.MaxAge(values[0]) }, nil
+	})
+	RegisterRule("is_url", func(args []string) (RuleFunc, error) {
+		var options []URLOption
+		schemes := make([]string, 0, len(args))
+		for _, arg := range args {
+			if arg == "host" {
+				options = append(options, URLRequireHost())
+			} else {
+				schemes = append(schemes, arg)
+			}
+		}
+		if len(schemes) > 0 {
+			options = append(options, URLSchemes(schemes...))
+		}
+		return func(b *Builder) *Builder { return b.IsURL(options...) }, nil
+	})
+	RegisterRule("email", func(args []string) (RuleFunc, error) {
+		var config EmailConfig
+		for _, arg := range args {
+			if arg == "disposable" {
+				config.BlockDisposable = true
+				continue
+			}
+			level, ok := emailLevels[arg]
+			if !ok {
+				return nil, fmt.Errorf("unknown email level %q", arg)
+			}
+			config.Level = level
+		}
+		return func(b *Builder) *Builder { return b.Email(config) }, nil
+	})
+	RegisterRule("phone", func(args []string) (RuleFunc, error) {
+		var region string
+		var types []PhoneType
+		for i, arg := range args {
+			if i == 0 {
+				region = arg
+				continue
+			}
+			phoneType, err := ParsePhoneType(arg)
+			if err != nil {
+				return nil, err
+			}
+			types = append(types, phoneType)
+		}
+		if _, ok := phoneRegions[strings.ToUpper(region)]; region != "" && !ok {
+			return nil, fmt.Errorf("unsupported phone region %q", region)
+		}
+		return func(b *Builder) *Builder { return b.Phone(region, types...) }, nil
+	})
+	RegisterRule("normalize_phone", func(args []string) (RuleFunc, error) {
+		if len(args) > 1 {
+			return nil, fmt.Errorf("expects at most 1 argument, got %d", len(args))
+		}
+		var region string
+		if len(args) == 1 {
+			region = args[0]
+		}
+		return func(b *Builder) *Builder { return b.NormalizePhone(region) }, nil
+	})
+	RegisterRule("is_credit_card", func(args []string) (RuleFunc, error) {
+		return func(b *Builder) *Builder { return b.IsCreditCard(args...) }, nil
+	})
+	RegisterRule("check_digit", func(args []string) (RuleFunc, error) {
+		if err := checkArgs(args, 1); err != nil {
+			return nil, err
+		}
+		algorithm, ok := LookupCheckDigit(args[0])
+		if !ok {
+			return nil, fmt.Errorf("unknown check digit algorithm %q", args[0])
+		}
+		return func(b *Builder) *Builder { return b.CheckDigit(algorithm) }, nil
+	})
+	RegisterRule("is_isbn", func(args []string) (RuleFunc, error) {
+		versions, err := parseLengthArgs(args, 10, 13)
+		if err != nil {
+			return nil, err
+		}
+		return func(b *Builder) *Builder { return b.IsISBN(versions...) }, nil
+	})
+	RegisterRule("is_gtin", func(args []string) (RuleFunc, error) {
+		lengths, err := parseLengthArgs(args, 8, 12, 13, 14)
+		if err != nil {
+			return nil, err
+		}
+		return func(b *Builder) *Builder { return b.IsGTIN(lengths...) }, nil
+	})
+	RegisterRule("password", func(args []string) (RuleFunc, error) {
+		policy := DefaultPasswordPolicy
+		if len(args) > 0 {
+			values, err := parseIntArgs(args, 1)
+			if err != nil {
+				return nil, err
+			}
+			policy.MinLength = values[0]
+		}
+		return func(b *Builder) *Builder { return b.Password(policy) }, nil
+	})
+	RegisterRule("ip_in_range", func(args []string) (RuleFunc, error) {
+		if len(args) == 0 {
+			return nil, fmt.Errorf("expects at least 1 argument")
+		}
+		prefixes, err := parsePrefixes(args)
+		if err != nil {
+			return nil, err
+		}
+		return func(b *Builder) *Builder { return b.ipInRange(args, prefixes) }, nil
+	})
+}
@@ -0,0 +1,89 @@
+package validation
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseRules(t *testing.T) {
+	rules, err := ParseRules("required| min:0 |one_of:a,b,c|")
+	if err != nil {
+		t.Fatal("Expected error to be nil, got: ", err)
+	}
+	expected := []Rule{
+		{Name: "required"},
+		{Name: "min", Args: []string{"0"}},
+		{Name: "one_of", Args: []string{"a", "b", "c"}},
+	}
+	if !reflect.DeepEqual(rules, expected) {
+		t.Fatalf("expected %v, got %v", expected, rules)
+	}
+	if _, err := ParseRules("required|:0"); err == nil {
+		t.Fatal("Expected error to be not nil")
+	}
+}
+
+func TestCompileRuleSet(t *testing.T) {
+	invalidRules := []string{
+		"unknown",
+		"min",
+		"min:a",
+		"range:1",
+		"min_length:1.5",
+		"required:1",
+		"one_of",
+		"min_date:yesterday",
+	}
+	for _, rules := range invalidRules {
+		if _, err := CompileRuleSet(rules); err == nil {
+			t.Fatalf(`rules %q are valid, they should be invalid`, rules)
+		}
+	}
+}
+
+func TestRuleSet(t *testing.T) {
+	testCases := []struct {
+		value    interface{}
+		rules    string
+		expected bool
+	}{
+		{"test", "required|min_length:3|max_length:10", true},
+		{"", "required", false},
+		{" Foo@Example.COM ", "trim|lower|is_email", true},
+		{nil, "trim|required", false},
+		{"5", "numeric|min:1|max:10", true},
+		{"5", "range:6,10", false},
+		{"b", "one_of:a,b", true},
+		{"c", "one_of:a,b", false},
+		{"2009-12-12", "between_date:2009-12-11,2009-12-13", true},
+		{"2009-12-12", "min_date:2009-12-13", false},
+		{[]string{"a"}, "min_count:1|max_count:1", true},
+		{(*string)(nil), "min_length:3|numeric|min_count:1", true},
+	}
+	for _, testCase := range testCases {
+		v := New()
+		v.Builder("test", testCase.value).Rules(testCase.rules)
+		if testCase.expected && v.Error() != nil {
+			t.Fatalf(`value %q is invalid with %q, it should be valid: %v`, testCase.value, testCase.rules, v.Error())
+		}
+		if !testCase.expected && v.Error() == nil {
+			t.Fatalf(`value %q is valid with %q, it should be invalid`, testCase.value, testCase.rules)
+		}
+	}
+}
+
+func TestRegisterRule(t *testing.T) {
+	RegisterRule("test_even", noArgs(func(b *Builder) *Builder {
+		return b.Custom(func(field string) *FieldError {
+			if value, ok := b.getInt(); ok && value%2 != 0 {
+				return NewFieldError(field, field+" must be even", "test_even", value)
+			}
+			return nil
+		})
+	}))
+	v := New()
+	v.Builder("test", 3).Rules("required|test_even")
+	if err := v.Error(); err == nil || err.(Error).Errors()["test"].Tag() != "test_even" {
+		t.Fatalf("expected a test_even error, got %v", err)
+	}
+}
@@ -0,0 +1,111 @@
+package validation
+
+import "sync"
+
+// Schema is a set of rules for the fields of T that is declared and compiled once,
+// then used to validate many values
+//
+// Once declared, a Schema is safe for concurrent use by multiple goroutines.
+// Declaring fields while validating is not
+type Schema[T any] struct {
+	fields []schemaField[T]
+	pool   sync.Pool
+}
+
+// schemaState is what the pool of a Schema holds, the builder is reused for every field
+type schemaState struct {
+	validation *Validation
+	builder    Builder
+}
+
+type schemaField[T any] struct {
+	name  string
+	get   func(*T) interface{}
+	rules *RuleSet
+	check func(*T) Validator
+}
+
+// NewSchema creates an empty Schema for T
+func NewSchema[T any]() *Schema[T] {
+	s := &Schema[T]{}
+	s.pool.New = func() interface{} { return &schemaState{validation: New()} }
+	return s
+}
+
+// Field declares a field named name, whose value is returned by get and validated with a
+// rule string such as "required|min_length:3"
+//
+// get is called for every validated value, returning a pointer to the field
+// avoids boxing it. Field panics if the rule string is invalid
+func (s *Schema[T]) Field(name string, get func(*T) interface{}, rules string) *Schema[T] {
+	return s.FieldRules(name, get, MustCompileRuleSet(rules))
+}
+
+// FieldRules is like Field but uses an already compiled rule set
+func (s *Schema[T]) FieldRules(name string, get func(*T) interface{}, rules *RuleSet) *Schema[T] {
+	s.fields = append(s.fields, schemaField[T]{name: name, get: get, rules: rules})
+	return s
+}
+
+// Check declares a validator for the field named name that is built from the whole value,
+// which is useful for rules depending on several fields
+func (s *Schema[T]) Check(name string, check func(*T) Validator) *Schema[T] {
+	s.fields = append(s.fields, schemaField[T]{name: name, check: check})
+	return s
+}
+
+// Rules returns the rule set of every field declared with Field or FieldRules, keyed by
+// field name, e.g. to describe the schema with the jsonschema package.
+// The validators declared with Check are plain Go code and are left out
+func (s *Schema[T]) Rules() map[string]*RuleSet {
+	rules := make(map[string]*RuleSet, len(s.fields))
+	for _, field := range s.fields {
+		if field.rules == nil {
+			continue
+		}
+		if prev, ok := rules[field.name]; ok {
+			rules[field.name] = &RuleSet{
+				rules: append(append([]Rule{}, prev.rules...), field.rules.rules...),
+				funcs: append(append([]RuleFunc{}, prev.funcs...), field.rules.funcs...),
+			}
+			continue
+		}
+		rules[field.name] = field.rules
+	}
+	return rules
+}
+
+// Validate validates value and returns the validation error, if any
+//
+// The Validation used is taken from a pool, so validating a valid value doesn't allocate
+// as long as the field getters return pointers
+func (s *Schema[T]) Validate(value *T) error {
+	state := s.pool.Get().(*schemaState)
+	v := state.validation
+	s.apply(v, &state.builder, value)
+	if err := v.Error(); err != nil {
+		// the error keeps the errors of v, so it can't go back to the pool
+		return err
+	}
+	v.Reset()
+	s.pool.Put(state)
+	return nil
+}
+
+// Apply validates value and adds the errors to v
+func (s *Schema[T]) Apply(v *Validation, value *T) {
+	s.apply(v, &Builder{}, value)
+}
+
+func (s *Schema[T]) apply(v *Validation, b *Builder, value *T) {
+	for i := range s.fields {
+		field := &s.fields[i]
+		if field.check != nil {
+			v.Add(field.name, field.check(value))
+			continue
+		}
+		*b = Builder{validation: v, field: field.name, value: field.get(value)}
+		field.rules.Apply(b)
+	}
+	*b = Builder{}
+}
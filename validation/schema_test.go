@@ -0,0 +1,130 @@
+package validation
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+)
+
+type schemaRow struct {
+	SKU      string
+	Name     string
+	Price    string
+	Quantity int
+	MinQty   int
+}
+
+// newRowSchema builds the schema in a function, so the built in rules
+// are registered by the time it is compiled
+func newRowSchema() *Schema[schemaRow] {
+	return NewSchema[schemaRow]().
+		Field("sku", func(r *schemaRow) interface{} { return &r.SKU }, "required|is_alphanumeric").
+		Field("name", func(r *schemaRow) interface{} { return &r.Name }, "trim|required|max_length:20").
+		Field("price", func(r *schemaRow) interface{} { return &r.Price }, "required|numeric|min:0").
+		Field("quantity", func(r *schemaRow) interface{} { return &r.Quantity }, "range:0,1000").
+		Check("quantity", func(r *schemaRow) Validator { return Min(r.Quantity, r.MinQty) })
+}
+
+func TestSchema(t *testing.T) {
+	rowSchema := newRowSchema()
+	testCases := []struct {
+		row    schemaRow
+		errors map[string]string
+	}{
+		{schemaRow{SKU: "a1", Name: " Widget ", Price: "10.5", Quantity: 5}, nil},
+		{schemaRow{SKU: "a-1", Name: "", Price: "free", Quantity: 5}, map[string]string{
+			"sku":   "is_alphanumeric",
+			"name":  "required",
+			"price": "numeric",
+		}},
+		// the getters return pointers, without trim the required rule sees a *string
+		{schemaRow{SKU: "", Name: "Widget", Price: "", Quantity: 5}, map[string]string{
+			"sku":   "required",
+			"price": "required",
+		}},
+		{schemaRow{SKU: "a1", Name: "Widget", Price: "1", Quantity: 1001}, map[string]string{"quantity": "range"}},
+		{schemaRow{SKU: "a1", Name: "Widget", Price: "1", Quantity: 2, MinQty: 5}, map[string]string{"quantity": "min"}},
+	}
+	for i, testCase := range testCases {
+		err := rowSchema.Validate(&testCase.row)
+		if testCase.errors == nil {
+			if err != nil {
+				t.Fatalf("case %d: Expected error to be nil, got: %v", i, err)
+			}
+			continue
+		}
+		if err == nil {
+			t.Fatalf("case %d: Expected error to be not nil", i)
+		}
+		errors := err.(Error).Errors()
+		if len(errors) != len(testCase.errors) {
+			t.Fatalf("case %d: expected %d errors, got %v", i, len(testCase.errors), err)
+		}
+		for field, tag := range testCase.errors {
+			if errors[field] == nil || errors[field].Tag() != tag {
+				t.Fatalf("case %d: expected %s to fail with %s, got %v", i, field, tag, errors[field])
+			}
+		}
+	}
+}
+
+func TestSchemaConcurrent(t *testing.T) {
+	rowSchema := newRowSchema()
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				row := schemaRow{SKU: "a" + strconv.Itoa(j), Name: "Widget", Price: strconv.Itoa(j), Quantity: j}
+				if j%2 == 1 {
+					row.Price = "-1"
+				}
+				err := rowSchema.Validate(&row)
+				if (err != nil) != (j%2 == 1) {
+					t.Errorf("goroutine %d row %d: unexpected result %v", i, j, err)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestSchemaDoesNotAllocate(t *testing.T) {
+	if raceEnabled {
+		t.Skip("the race detector allocates")
+	}
+	row := schemaRow{SKU: "a1", Name: "Widget", Price: "10.5", Quantity: 5}
+	schema := NewSchema[schemaRow]().
+		Field("sku", func(r *schemaRow) interface{} { return &r.SKU }, "required|is_alphanumeric").
+		Field("quantity", func(r *schemaRow) interface{} { return &r.Quantity }, "range:0,1000")
+	_ = schema.Validate(&row)
+	allocs := testing.AllocsPerRun(100, func() {
+		if err := schema.Validate(&row); err != nil {
+			t.Fatal("Expected error to be nil, got: ", err)
+		}
+	})
+	if allocs != 0 {
+		t.Fatalf("expected no allocations, got %v", allocs)
+	}
+}
+
+func TestSchemaInvalidRules(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected a panic for invalid rules")
+		}
+	}()
+	NewSchema[schemaRow]().Field("sku", func(r *schemaRow) interface{} { return &r.SKU }, "unknown")
+}
+
+func BenchmarkSchema(b *testing.B) {
+	rowSchema := newRowSchema()
+	row := schemaRow{SKU: "a1", Name: "Widget", Price: "10.5", Quantity: 5}
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = rowSchema.Validate(&row)
+		}
+	})
+}
@@ -0,0 +1,119 @@
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// StructTag is the struct tag holding the rule string of a field
+const StructTag = "validate"
+
+var structRules sync.Map // map[reflect.Type]*MapRules
+
+// StructRules returns the rule strings declared with the validate tag of a struct type,
+// keyed by path like the rules of MapRules
+//
+// Fields are named after their json tag, or their Go name if there is none.
+// Nested structs are walked, and the elements of slices, arrays and maps are
+// addressed using "*", e.g. "items.*.price".
+// v can be a struct, a pointer to a struct or a reflect.Type
+func StructRules(v interface{}) (map[string]string, error) {
+	t, ok := v.(reflect.Type)
+	if !ok {
+		t = reflect.TypeOf(v)
+	}
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("validation: %v is not a struct", t)
+	}
+	rules := make(map[string]string)
+	collectStructRules(t, "", rules, map[reflect.Type]bool{})
+	return rules, nil
+}
+
+func collectStructRules(t reflect.Type, prefix string, rules map[string]string, visiting map[reflect.Type]bool) {
+	if visiting[t] {
+		return
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, ok := FieldName(field)
+		if !ok {
+			continue
+		}
+		path := name
+		if prefix != "" {
+			path = prefix + "." + name
+		}
+		if rule := field.Tag.Get(StructTag); rule != "" && rule != "-" {
+			rules[path] = rule
+		}
+		collectNestedRules(field.Type, path, rules, visiting)
+	}
+}
+
+func collectNestedRules(t reflect.Type, path string, rules map[string]string, visiting map[reflect.Type]bool) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		collectStructRules(t, path, rules, visiting)
+	case reflect.Slice, reflect.Array, reflect.Map:
+		collectNestedRules(t.Elem(), path+".*", rules, visiting)
+	}
+}
+
+// FieldName returns the name of a struct field as used in field errors,
+// which is its json tag name or its Go name if there is none
+//
+// It returns false for unexported fields and fields tagged with json:"-"
+func FieldName(field reflect.StructField) (string, bool) {
+	if field.PkgPath != "" {
+		return "", false
+	}
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "-" {
+		return "", false
+	}
+	if name == "" {
+		name = field.Name
+	}
+	return name, true
+}
+
+// ValidateStruct validates a struct using the rules declared with the validate tag
+// and returns the validation error, if any
+//
+// It panics if a rule string is invalid
+func ValidateStruct(s interface{}) error {
+	v := New()
+	v.Struct(s)
+	return v.Error()
+}
+
+// Struct validates a struct using the rules declared with the validate tag
+//
+// The rules of each struct type are compiled once and cached.
+// It panics if s is not a struct or a rule string is invalid
+func (v *Validation) Struct(s interface{}) {
+	t := reflect.TypeOf(s)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	compiled, ok := structRules.Load(t)
+	if !ok {
+		rules, err := StructRules(t)
+		if err != nil {
+			panic(err)
+		}
+		compiled, _ = structRules.LoadOrStore(t, MustCompileMapRules(rules))
+	}
+	compiled.(*MapRules).applyValue(v, reflect.ValueOf(s))
+}
@@ -0,0 +1,103 @@
+package validation
+
+import (
+	"reflect"
+	"testing"
+)
+
+type testAddress struct {
+	City   string `json:"city" validate:"required"`
+	Postal string `json:"postal_code" validate:"is_only_digits|length:5,5"`
+}
+
+type testItem struct {
+	SKU   string  `json:"sku" validate:"required|is_alphanumeric"`
+	Price float64 `json:"price" validate:"min:0"`
+}
+
+type testOrder struct {
+	Name     string       `json:"name" validate:"required|min_length:3"`
+	Email    *string      `json:"email,omitempty" validate:"is_email"`
+	Address  *testAddress `json:"address"`
+	Items    []testItem   `json:"items" validate:"min_count:1"`
+	Quantity int          `validate:"range:1,10"`
+	Ignored  string       `json:"-" validate:"required"`
+	internal string
+}
+
+func TestStructRules(t *testing.T) {
+	rules, err := StructRules(&testOrder{})
+	if err != nil {
+		t.Fatal("Expected error to be nil, got: ", err)
+	}
+	expected := map[string]string{
+		"name":                "required|min_length:3",
+		"email":               "is_email",
+		"address.city":        "required",
+		"address.postal_code": "is_only_digits|length:5,5",
+		"items":               "min_count:1",
+		"items.*.sku":         "required|is_alphanumeric",
+		"items.*.price":       "min:0",
+		"Quantity":            "range:1,10",
+	}
+	if !reflect.DeepEqual(rules, expected) {
+		t.Fatalf("expected %v, got %v", expected, rules)
+	}
+	if _, err := StructRules("not a struct"); err == nil {
+		t.Fatal("Expected error to be not nil")
+	}
+}
+
+func TestValidateStruct(t *testing.T) {
+	valid := testOrder{
+		Name:     "order",
+		Address:  &testAddress{City: "Bandung", Postal: "40111"},
+		Items:    []testItem{{SKU: "a1", Price: 10}},
+		Quantity: 2,
+	}
+	if err := ValidateStruct(&valid); err != nil {
+		t.Fatal("Expected error to be nil, got: ", err)
+	}
+
+	email := "not an email"
+	invalid := testOrder{
+		Name:     "or",
+		Email:    &email,
+		Address:  &testAddress{Postal: "4011"},
+		Items:    []testItem{{SKU: "a1", Price: -1}, {SKU: ""}},
+		Quantity: 11,
+	}
+	err := ValidateStruct(invalid)
+	if err == nil {
+		t.Fatal("Expected error to be not nil")
+	}
+	expected := map[string]string{
+		"name":                "min_length",
+		"email":               "is_email",
+		"address.city":        "required",
+		"address.postal_code": "length",
+		"items.0.price":       "min",
+		"items.1.sku":         "required",
+		"Quantity":            "range",
+	}
+	errors := err.(Error).Errors()
+	if len(errors) != len(expected) {
+		t.Fatalf("expected %d errors, got %v", len(expected), err)
+	}
+	for field, tag := range expected {
+		if errors[field] == nil || errors[field].Tag() != tag {
+			t.Fatalf("expected %s to fail with %s, got %v", field, tag, errors[field])
+		}
+	}
+}
+
+func TestValidateStructNilNested(t *testing.T) {
+	err := ValidateStruct(testOrder{Name: "order", Items: []testItem{{SKU: "a"}}, Quantity: 1})
+	if err == nil {
+		t.Fatal("Expected error to be not nil")
+	}
+	errors := err.(Error).Errors()
+	if len(errors) != 1 || errors["address.city"] == nil {
+		t.Fatalf("expected only address.city to fail, got %v", err)
+	}
+}
@@ -0,0 +1,62 @@
+package validation
+
+import "strings"
+
+// Transform replaces the value with the result of fn, so the following rules
+// see the normalized value
+//
+// Values that are not strings are formatted using fmt before being passed to fn.
+// Nil values are left untouched
+func (v *Builder) Transform(fn func(string) string) *Builder {
+	if v.hasError() || !v.isSet() {
+		return v
+	}
+	value, ok := v.getString()
+	if ok {
+		v.value = fn(value)
+	}
+	return v
+}
+
+// Trim removes leading and trailing white space from the value
+func (v *Builder) Trim() *Builder {
+	return v.Transform(strings.TrimSpace)
+}
+
+// Lower converts the value to lower case
+func (v *Builder) Lower() *Builder {
+	return v.Transform(strings.ToLower)
+}
+
+// Upper converts the value to upper case
+func (v *Builder) Upper() *Builder {
+	return v.Transform(strings.ToUpper)
+}
+
+// CollapseSpaces trims the value and replaces every run of white space with a single space
+func (v *Builder) CollapseSpaces() *Builder {
+	return v.Transform(collapseSpaces)
+}
+
+// StripNonDigits removes every character that is not an ASCII digit from the value
+func (v *Builder) StripNonDigits() *Builder {
+	return v.Transform(stripNonDigits)
+}
+
+// Value returns the current value of the builder, including any transformation applied to it
+func (v *Builder) Value() interface{} {
+	return v.value
+}
+
+func collapseSpaces(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func stripNonDigits(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, s)
+}
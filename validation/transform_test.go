@@ -0,0 +1,66 @@
+package validation
+
+import "testing"
+
+func TestTransform(t *testing.T) {
+	testCases := []struct {
+		value    interface{}
+		build    func(b *Builder) *Builder
+		expected string
+	}{
+		{" Foo@Example.COM ", func(b *Builder) *Builder { return b.Trim().Lower() }, "foo@example.com"},
+		{"foo", func(b *Builder) *Builder { return b.Upper() }, "FOO"},
+		{"  foo \t bar\n baz ", func(b *Builder) *Builder { return b.CollapseSpaces() }, "foo bar baz"},
+		{"+62 (812) 3456-7890", func(b *Builder) *Builder { return b.StripNonDigits() }, "6281234567890"},
+		{12345, func(b *Builder) *Builder { return b.Transform(func(s string) string { return s[:2] }) }, "12"},
+	}
+	for _, testCase := range testCases {
+		v := New()
+		b := testCase.build(v.Builder("test", testCase.value))
+		if b.Value() != testCase.expected {
+			t.Fatalf(`value %q should be transformed into %q, got %q`, testCase.value, testCase.expected, b.Value())
+		}
+	}
+}
+
+func TestTransformBeforeRules(t *testing.T) {
+	v := New()
+	var email string
+	v.Builder("email", " Foo@Example.COM ").
+		Trim().
+		Lower().
+		IsEmail().
+		IntoString(&email)
+	if err := v.Error(); err != nil {
+		t.Fatal("Expected error to be nil, got: ", err)
+	}
+	if email != "foo@example.com" {
+		t.Fatalf(`expected normalized email, got %q`, email)
+	}
+}
+
+func TestTransformNil(t *testing.T) {
+	v := New()
+	var value *string
+	b := v.Builder("test", value).Trim()
+	if b.Value() != value {
+		t.Fatal("nil value should not be transformed")
+	}
+}
+
+func TestTransformNilRequired(t *testing.T) {
+	v := New()
+	v.Builder("test", nil).Trim().Lower().Required()
+	if v.Error() == nil {
+		t.Fatal("nil value should still fail Required after a transform")
+	}
+}
+
+func TestIntoStringWithError(t *testing.T) {
+	v := New()
+	dst := "untouched"
+	v.Builder("test", " ").Trim().Required().IntoString(&dst)
+	if dst != "untouched" {
+		t.Fatalf(`destination should not be written when the field has an error, got %q`, dst)
+	}
+}
@@ -1,19 +1,68 @@
 package validation
 
+import "time"
+
 type Validation struct {
 	error       error
 	fieldErrors map[string]*FieldError
+	lengthMode  LengthMode
+	resolver    Resolver
+	clock       Clock
+	layouts     []string
+	dateOrder   DateOrder
+	location    *time.Location
+	calendar    Calendar
 }
 
 func New() *Validation {
 	errors := make(map[string]*FieldError, 0)
-	return &Validation{error: nil, fieldErrors: errors}
+	return &Validation{error: nil, fieldErrors: errors, lengthMode: Bytes}
 }
 
 func (v *Validation) Builder(field string, value interface{}) *Builder {
 	return NewBuilder(v, field, value)
 }
 
+// SetLengthMode sets how the builders count the length of strings, the default is Bytes
+func (v *Validation) SetLengthMode(mode LengthMode) {
+	v.lengthMode = mode
+}
+
+// SetResolver sets the resolver used by the builders to resolve host names,
+// the default is net.DefaultResolver
+func (v *Validation) SetResolver(resolver Resolver) {
+	v.resolver = resolver
+}
+
+// SetClock sets the clock used by the builders, the default is SystemClock
+func (v *Validation) SetClock(clock Clock) {
+	v.clock = clock
+}
+
+// SetDateLayouts sets the layouts tried in order by the builders to parse dates,
+// the default is DefaultDateLayouts of the date order of the validation
+func (v *Validation) SetDateLayouts(layouts ...string) {
+	v.layouts = layouts
+}
+
+// SetDateOrder sets how the default layouts read ambiguous dates like "03/04/2024",
+// the default is MonthFirst
+func (v *Validation) SetDateOrder(order DateOrder) {
+	v.dateOrder = order
+}
+
+// SetLocation sets the location of the dates parsed by the builders without a timezone,
+// the default is UTC
+func (v *Validation) SetLocation(loc *time.Location) {
+	v.location = loc
+}
+
+// SetCalendar sets the calendar used by the builders to tell business days apart,
+// the default has Saturday and Sunday as the weekend and no holidays
+func (v *Validation) SetCalendar(calendar Calendar) {
+	v.calendar = calendar
+}
+
 func (v *Validation) Add(field string, validations ...Validator) {
 	for _, validation := range validations {
 		if _, ok := v.fieldErrors[field]; ok {
@@ -31,6 +80,16 @@ func (v *Validation) AddError(field string, err *FieldError) {
 	}
 }
 
+// Reset removes every error, so the Validation can be reused, e.g. from a sync.Pool
+//
+// The errors returned by Error before Reset must not be used afterwards
+func (v *Validation) Reset() {
+	for field := range v.fieldErrors {
+		delete(v.fieldErrors, field)
+	}
+	v.error = nil
+}
+
 func (v *Validation) Error() error {
 	if len(v.fieldErrors) > 0 {
 		v.error = NewError(v.fieldErrors)
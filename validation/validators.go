@@ -2,6 +2,7 @@ package validation
 
 import (
 	"fmt"
+	"math/big"
 	"net/mail"
 	"reflect"
 	"regexp"
@@ -13,27 +14,39 @@ import (
 
 type Validator func(field string) *FieldError
 
-// Required checks if the data is nil or empty string
+var (
+	alphanumericRegex = regexp.MustCompile(`^([a-zA-Z0-9])+$`)
+	phoneRegex        = regexp.MustCompile(`^(\+|0)([0-9])+$`)
+	onlyDigitsRegex   = regexp.MustCompile(`^[0-9]+$`)
+)
+
+// Required checks if the data is nil, a nil pointer or an empty string.
+// A *string pointing to an empty string is empty as well
 func Required(data interface{}) Validator {
 	return func(field string) *FieldError {
-		msg := fmt.Sprintf("%s is required", field)
-		if data == nil || (reflect.ValueOf(data).Kind() == reflect.Ptr && reflect.ValueOf(data).IsNil()) {
+		value := data
+		if ptr, ok := value.(*string); ok && ptr != nil {
+			value = *ptr
+		}
+		if value == nil || (reflect.ValueOf(value).Kind() == reflect.Ptr && reflect.ValueOf(value).IsNil()) {
+			msg := fmt.Sprintf("%s is required", field)
 			return NewFieldError(field, msg, "required", nil)
 		}
-		if str, ok := data.(string); ok && str == "" {
+		if str, ok := value.(string); ok && str == "" {
+			msg := fmt.Sprintf("%s is required", field)
 			return NewFieldError(field, msg, "required", str)
 		}
 		return nil
 	}
 }
 
-// Min checks if the data is at least min
+// Min checks if the data is at least min, NaN is never valid
 //
 // Has one parameter: min (any number type, except complex)
 func Min[T Number](data T, min T) Validator {
 	return func(field string) *FieldError {
-		msg := fmt.Sprintf("%s must be at least %v", field, min)
-		if data < min {
+		if data < min || isNaN(data) {
+			msg := fmt.Sprintf("%s must be at least %v", field, min)
 			err := NewFieldError(field, msg, "min", data)
 			err.SetParam("min", min)
 			return err
@@ -42,13 +55,13 @@ func Min[T Number](data T, min T) Validator {
 	}
 }
 
-// Max checks if the data is at most max
+// Max checks if the data is at most max, NaN is never valid
 //
 // Has one parameter: max (any number type, except complex)
 func Max[T Number](data T, max T) Validator {
 	return func(field string) *FieldError {
-		msg := fmt.Sprintf("%s must be at most %v", field, max)
-		if data > max {
+		if data > max || isNaN(data) {
+			msg := fmt.Sprintf("%s must be at most %v", field, max)
 			err := NewFieldError(field, msg, "max", data)
 			err.SetParam("max", max)
 			return err
@@ -57,13 +70,13 @@ func Max[T Number](data T, max T) Validator {
 	}
 }
 
-// Range checks if the data is between min and max
+// Range checks if the data is between min and max, NaN is never valid
 //
 // Has two parameters: min (any number type, except complex), max (same type as min)
 func Range[T Number](data T, min T, max T) Validator {
 	return func(field string) *FieldError {
-		msg := fmt.Sprintf("%s must be between %v and %v", field, min, max)
-		if data < min || data > max {
+		if data < min || data > max || isNaN(data) {
+			msg := fmt.Sprintf("%s must be between %v and %v", field, min, max)
 			err := NewFieldError(field, msg, "range", data)
 			err.SetParam("min", min)
 			err.SetParam("max", max)
@@ -73,50 +86,25 @@ func Range[T Number](data T, min T, max T) Validator {
 	}
 }
 
-// MinLength checks if the data is at least min characters long
+// MinLength checks if the data is at least min characters long, counting bytes
 //
-// Has one parameter: min (int)
+// Has two parameters: min (int), mode (string), see MinLengthIn to count runes or graphemes
 func MinLength(data string, min int) Validator {
-	return func(field string) *FieldError {
-		msg := fmt.Sprintf("%s must be at least %d characters long", field, min)
-		if len(data) < min {
-			err := NewFieldError(field, msg, "min_length", data)
-			err.SetParam("min", min)
-			return err
-		}
-		return nil
-	}
+	return MinLengthIn(data, min, Bytes)
 }
 
-// MaxLength checks if the data is at most max characters long
+// MaxLength checks if the data is at most max characters long, counting bytes
 //
-// Has one parameter: max (int)
+// Has two parameters: max (int), mode (string), see MaxLengthIn to count runes or graphemes
 func MaxLength(data string, max int) Validator {
-	return func(field string) *FieldError {
-		msg := fmt.Sprintf("%s must be at most %d characters long", field, max)
-		if len(data) > max {
-			err := NewFieldError(field, msg, "max_length", data)
-			err.SetParam("max", max)
-			return err
-		}
-		return nil
-	}
+	return MaxLengthIn(data, max, Bytes)
 }
 
-// Length checks if the data is between min and max characters long
+// Length checks if the data is between min and max characters long, counting bytes
 //
-// Has one parameter: length (int)
+// Has three parameters: min (int), max (int), mode (string), see LengthIn to count runes or graphemes
 func Length(data string, min int, max int) Validator {
-	return func(field string) *FieldError {
-		msg := fmt.Sprintf("%s must be between %d and %d characters long", field, min, max)
-		if len(data) < min || len(data) > max {
-			err := NewFieldError(field, msg, "length", data)
-			err.SetParam("min", min)
-			err.SetParam("max", max)
-			return err
-		}
-		return nil
-	}
+	return LengthIn(data, min, max, Bytes)
 }
 
 // OneOf checks if the data is in the collection
@@ -137,6 +125,9 @@ func OneOf(item string, collection ...string) Validator {
 }
 
 // IsEmail checks if the data is a valid email address
+//
+// It uses net/mail, which also accepts a display name, e.g. "Name <user@example.com>",
+// use Email to only accept the address
 func IsEmail(email string) Validator {
 	return func(field string) *FieldError {
 		_, emailErr := mail.ParseAddress(email)
@@ -151,7 +142,7 @@ func IsEmail(email string) Validator {
 // IsAlphanumeric checks if the data is alphanumeric excluding space
 func IsAlphanumeric(value string) Validator {
 	return func(field string) *FieldError {
-		if !regexp.MustCompile(`^([a-zA-Z0-9])+$`).Match([]byte(value)) {
+		if !alphanumericRegex.MatchString(value) {
 			msg := fmt.Sprintf("%s must be alphanumeric", field)
 			return NewFieldError(field, msg, "is_alphanumeric", value)
 		}
@@ -182,9 +173,12 @@ func IsISO8601Date(date string) Validator {
 }
 
 // IsPhone checks if the data is a valid phone number
+//
+// It only checks the number starts with + or 0 followed by digits,
+// use Phone to check it against the numbering plan of its region
 func IsPhone(phone string) Validator {
 	return func(field string) *FieldError {
-		if !regexp.MustCompile(`^(\+|0)([0-9])+$`).Match([]byte(phone)) {
+		if !phoneRegex.MatchString(phone) {
 			msg := fmt.Sprintf("%s is not a valid phone number", field)
 			return NewFieldError(field, msg, "is_phone", phone)
 		}
@@ -206,7 +200,7 @@ func IsUUID(input string) Validator {
 // IsOnlyDigits checks if the data contains only digits
 func IsOnlyDigits(input string) Validator {
 	return func(field string) *FieldError {
-		if !regexp.MustCompile(`^[0-9]+$`).Match([]byte(input)) {
+		if !onlyDigitsRegex.MatchString(input) {
 			msg := fmt.Sprintf("%s contains non-digit characters", field)
 			return NewFieldError(field, msg, "is_only_digits", input)
 		}
@@ -308,24 +302,70 @@ func MaxCount(array interface{}, max int) Validator {
 	}
 }
 
-// Numeric checks if the given value is a number
+// MinCountOf checks if the length of the slice is greater than or equal to the given number
+//
+// It is the same as MinCount without reflection. Has one parameter: min (int)
+func MinCountOf[T any](items []T, min int) Validator {
+	return func(field string) *FieldError {
+		if len(items) < min {
+			msg := fmt.Sprintf("%s must have at least %d items", field, min)
+			err := NewFieldError(field, msg, "min_count", items)
+			err.SetParam("min", min)
+			return err
+		}
+		return nil
+	}
+}
+
+// MaxCountOf checks if the length of the slice is less than or equal to the given number
+//
+// It is the same as MaxCount without reflection. Has one parameter: max (int)
+func MaxCountOf[T any](items []T, max int) Validator {
+	return func(field string) *FieldError {
+		if len(items) > max {
+			msg := fmt.Sprintf("%s must have at most %d items", field, max)
+			err := NewFieldError(field, msg, "max_count", items)
+			err.SetParam("max", max)
+			return err
+		}
+		return nil
+	}
+}
+
+// Numeric checks if the given value is a number, including *big.Int, *big.Rat and *big.Float
+//
+// Pointers are dereferenced, a nil pointer is not a number
 func Numeric(value interface{}) Validator {
 	return func(field string) *FieldError {
-		msg := fmt.Sprintf("%s must be a number", field)
-		fieldError := NewFieldError(field, msg, "numeric", value)
-		switch reflect.TypeOf(value).Kind() {
+		switch number := value.(type) {
+		case *big.Int:
+			if number != nil {
+				return nil
+			}
+		case *big.Rat:
+			if number != nil {
+				return nil
+			}
+		case *big.Float:
+			if number != nil && !number.IsInf() {
+				return nil
+			}
+		}
+		rv := reflect.ValueOf(value)
+		for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+			rv = rv.Elem()
+		}
+		switch rv.Kind() {
 		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
 			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
 			reflect.Float32, reflect.Float64:
 			return nil
 		case reflect.String:
-			_, err := strconv.ParseFloat(value.(string), 64)
-			if err != nil {
-				return fieldError
+			if _, err := strconv.ParseFloat(rv.String(), 64); err == nil {
+				return nil
 			}
-			return nil
-		default:
-			return fieldError
 		}
+		msg := fmt.Sprintf("%s must be a number", field)
+		return NewFieldError(field, msg, "numeric", value)
 	}
 }
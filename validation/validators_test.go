@@ -518,6 +518,30 @@ func TestMaxCount(t *testing.T) {
 	}
 }
 
+func TestCountOf(t *testing.T) {
+	testCases := []struct {
+		value    []int
+		min      int
+		max      int
+		expected bool
+	}{
+		{[]int{1, 2, 3}, 1, 3, true},
+		{[]int{1, 2, 3}, 4, 5, false},
+		{[]int{1, 2, 3}, 1, 2, false},
+		{nil, 0, 0, true},
+	}
+	for _, testCase := range testCases {
+		v := New()
+		v.Add("test", MinCountOf(testCase.value, testCase.min), MaxCountOf(testCase.value, testCase.max))
+		if testCase.expected && v.Error() != nil {
+			t.Fatalf(`value %v is invalid, it should be valid`, testCase.value)
+		}
+		if !testCase.expected && v.Error() == nil {
+			t.Fatalf(`value %v is valid, it should be invalid`, testCase.value)
+		}
+	}
+}
+
 func TestNumeric(t *testing.T) {
 	testCases := []struct {
 		value    interface{}
@@ -529,6 +553,9 @@ func TestNumeric(t *testing.T) {
 		{"1.0", true},
 		{"1.0.0", false},
 		{"a", false},
+		{ptr("1.5"), true},
+		{ptr(1), true},
+		{(*int)(nil), false},
 	}
 	for _, testCase := range testCases {
 		v := New()
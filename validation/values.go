@@ -0,0 +1,119 @@
+package validation
+
+import (
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ValuesValidation validates url.Values, such as parsed query parameters or form values
+//
+// Keys are normalized into dot notation, so "filter[status]" can be accessed as
+// "filter.status", and repeated keys like "tags[]" are accessed as "tags".
+// The normalized key is used as the field name of the errors
+type ValuesValidation struct {
+	*Validation
+	values map[string][]string
+}
+
+// Values creates a new ValuesValidation for vals
+func Values(vals url.Values) *ValuesValidation {
+	return NewValues(New(), vals)
+}
+
+// NewValues creates a new ValuesValidation that adds its errors to v
+func NewValues(v *Validation, vals url.Values) *ValuesValidation {
+	values := make(map[string][]string, len(vals))
+	for key, val := range vals {
+		key = normalizeKey(key)
+		values[key] = append(values[key], val...)
+	}
+	return &ValuesValidation{v, values}
+}
+
+// Field returns a builder for the first value of key
+//
+// If the key is missing, the value is a nil *string,
+// so Required fails and the other validators are skipped
+func (v *ValuesValidation) Field(key string) *Builder {
+	key = normalizeKey(key)
+	vals := v.values[key]
+	if len(vals) == 0 {
+		return v.Builder(key, (*string)(nil))
+	}
+	return v.Builder(key, vals[0])
+}
+
+// Slice returns a builder for every value of key as a []string
+//
+// This is useful for validators that work on collections such as MinCount and MaxCount
+func (v *ValuesValidation) Slice(key string) *Builder {
+	key = normalizeKey(key)
+	vals := v.values[key]
+	if vals == nil {
+		vals = []string{}
+	}
+	return v.Builder(key, vals)
+}
+
+// Each calls fn with a builder for every value of key
+//
+// The fields are named using the index of the value, e.g. "tags.0", "tags.1"
+func (v *ValuesValidation) Each(key string, fn func(b *Builder)) {
+	key = normalizeKey(key)
+	for i, val := range v.values[key] {
+		fn(v.Builder(key+"."+strconv.Itoa(i), val))
+	}
+}
+
+// Has reports whether key is present
+func (v *ValuesValidation) Has(key string) bool {
+	_, ok := v.values[normalizeKey(key)]
+	return ok
+}
+
+// Keys returns the sorted sub keys of a bracket notation group
+//
+// For "filter[status]=open&filter[owner]=me", Keys("filter") returns ["owner", "status"]
+func (v *ValuesValidation) Keys(prefix string) []string {
+	prefix = normalizeKey(prefix) + "."
+	seen := make(map[string]bool)
+	keys := make([]string, 0)
+	for key := range v.values {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		sub := strings.TrimPrefix(key, prefix)
+		if i := strings.IndexByte(sub, '.'); i >= 0 {
+			sub = sub[:i]
+		}
+		if !seen[sub] {
+			seen[sub] = true
+			keys = append(keys, sub)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// normalizeKey converts bracket notation into dot notation,
+// e.g. "filter[status]" into "filter.status" and "tags[]" into "tags"
+func normalizeKey(key string) string {
+	if !strings.ContainsAny(key, "[]") {
+		return key
+	}
+	var sb strings.Builder
+	for i := 0; i < len(key); i++ {
+		switch key[i] {
+		case '[':
+			if i+1 < len(key) && key[i+1] != ']' {
+				sb.WriteByte('.')
+			}
+		case ']':
+		default:
+			sb.WriteByte(key[i])
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,104 @@
+package validation
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestValues(t *testing.T) {
+	vals, _ := url.ParseQuery("page=2&tags=a&tags=b&filter[status]=open&filter[owner]=me&ids[]=1&ids[]=2")
+	v := Values(vals)
+
+	var page int
+	v.Field("page").Required().MinInt(1).Into(&page)
+	v.Slice("tags").MinCount(1).MaxCount(5)
+	v.Each("tags", func(b *Builder) {
+		b.IsAlphanumeric()
+	})
+	v.Field("filter[status]").OneOf("open", "closed")
+	v.Field("filter.owner").Required()
+	v.Each("ids", func(b *Builder) {
+		b.IsOnlyDigits()
+	})
+	v.Field("q").MinLength(3)
+
+	if err := v.Error(); err != nil {
+		t.Fatal("Expected error to be nil, got: ", err)
+	}
+	if page != 2 {
+		t.Fatalf("expected page to be 2, got %d", page)
+	}
+	if keys := v.Keys("filter"); !reflect.DeepEqual(keys, []string{"owner", "status"}) {
+		t.Fatalf("unexpected keys %v", keys)
+	}
+	if !v.Has("ids") || v.Has("q") {
+		t.Fatal("unexpected result of Has")
+	}
+}
+
+func TestValuesOptional(t *testing.T) {
+	vals, _ := url.ParseQuery("q=x")
+	v := Values(vals)
+	page := 1
+	v.Field("page").MinInt(1).MaxInt(100).Into(&page)
+	v.Field("limit").MinUint(1).MultipleOf(10)
+	v.Field("price").MinFloat(0).Positive()
+	v.Field("since").MinDate(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)).InPast()
+	v.Field("timeout").MaxDuration(time.Minute)
+	if err := v.Error(); err != nil {
+		t.Fatal("Expected error to be nil, got: ", err)
+	}
+	if page != 1 {
+		t.Fatalf("absent page should not be written, got %d", page)
+	}
+
+	v = Values(vals)
+	v.Field("page").Required().MinInt(1)
+	if err := v.Error(); err == nil || err.(Error).Errors()["page"].Tag() != "required" {
+		t.Fatalf("expected a required error, got %v", err)
+	}
+}
+
+func TestValuesErrors(t *testing.T) {
+	vals, _ := url.ParseQuery("page=abc&tags=a&tags=b-c&filter[status]=pending")
+	v := Values(vals)
+	v.Field("page").MinInt(1)
+	v.Each("tags", func(b *Builder) {
+		b.IsAlphanumeric()
+	})
+	v.Field("filter[status]").OneOf("open", "closed")
+	v.Field("q").Required()
+
+	err := v.Error()
+	if err == nil {
+		t.Fatal("Expected error to be not nil")
+	}
+	errors := err.(Error).Errors()
+	for _, field := range []string{"page", "tags.1", "filter.status", "q"} {
+		if errors[field] == nil {
+			t.Fatalf("expected an error for %s, got %v", field, errors)
+		}
+	}
+	if len(errors) != 4 {
+		t.Fatalf("expected 4 errors, got %d", len(errors))
+	}
+}
+
+func TestNormalizeKey(t *testing.T) {
+	testCases := []struct {
+		key      string
+		expected string
+	}{
+		{"page", "page"},
+		{"tags[]", "tags"},
+		{"filter[status]", "filter.status"},
+		{"items[0][name]", "items.0.name"},
+	}
+	for _, testCase := range testCases {
+		if key := normalizeKey(testCase.key); key != testCase.expected {
+			t.Fatalf("key %q should be normalized into %q, got %q", testCase.key, testCase.expected, key)
+		}
+	}
+}